@@ -0,0 +1,171 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// AudioBackend plays audio files, selected with the --audio flag.
+type AudioBackend interface {
+	Play(path string) error
+	Name() string
+}
+
+// commandBackend plays audio with an external player.
+type commandBackend struct {
+	command string
+	args    []string
+}
+
+func (b commandBackend) Play(path string) error {
+	cmd := exec.Command(b.command, append(b.args, path)...)
+	cmd.Stdout = io.Discard
+	cmd.Stderr = io.Discard
+	return cmd.Run()
+}
+
+func (b commandBackend) Name() string { return b.command }
+
+// noopBackend plays nothing, used when muted or no player is found. The
+// cries are not downloaded when muted.
+type noopBackend struct {
+	muted bool
+}
+
+func (noopBackend) Play(path string) error { return nil }
+func (noopBackend) Name() string           { return "none" }
+
+// exportBackend copies the audio files to a directory instead of playing
+// them, for headless environments.
+type exportBackend struct {
+	dir string
+}
+
+func (b exportBackend) Play(path string) error {
+	if err := os.MkdirAll(b.dir, 0o755); err != nil {
+		return err
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(filepath.Join(b.dir, filepath.Base(path)), data, 0o644)
+}
+
+func (b exportBackend) Name() string { return "export to " + b.dir }
+
+// audioPlayers are the players tried in order, they must support OGG.
+var audioPlayers = []commandBackend{
+	{command: "ffplay", args: []string{"-nodisp", "-autoexit", "-loglevel", "quiet"}},
+	{command: "mpv", args: []string{"--no-video", "--really-quiet"}},
+	{command: "paplay"},
+	{command: "ogg123", args: []string{"-q"}},
+	{command: "cvlc", args: []string{"--play-and-exit", "--quiet"}},
+}
+
+var audioBackend AudioBackend = noopBackend{}
+
+// newAudioBackend returns the backend named name, auto picks the first
+// player found in the PATH.
+func newAudioBackend(name string, exportDir string) (AudioBackend, error) {
+	switch name {
+	case "none":
+		return noopBackend{}, nil
+	case "export":
+		return exportBackend{dir: exportDir}, nil
+	case "auto":
+		for _, player := range audioPlayers {
+			if _, err := exec.LookPath(player.command); err == nil {
+				return player, nil
+			}
+		}
+		return noopBackend{}, nil
+	}
+
+	for _, player := range audioPlayers {
+		if player.command == name {
+			return player, nil
+		}
+	}
+	return nil, fmt.Errorf("unknown audio backend %s", name)
+}
+
+type CryMsg struct {
+	Pokemon string
+	Path    string
+	Err     error
+}
+
+// getCry downloads the cry at url into the cache directory, unless it was
+// downloaded before, and returns its path.
+func getCry(pokemon string, url string, legacy bool) (string, error) {
+	if url == "" {
+		return "", fmt.Errorf("%s has no cry", pokemon)
+	}
+
+	kind := "latest"
+	if legacy {
+		kind = "legacy"
+	}
+	path, err := cacheFile(filepath.Join("cries", fmt.Sprintf("%s-%s.ogg", pokemon, kind)))
+	if err != nil {
+		return "", err
+	}
+	if _, err := os.Stat(path); err == nil {
+		return path, nil
+	}
+
+	data, err := fetchBytes(url, "cry")
+	if err != nil {
+		return "", err
+	}
+	if err := os.WriteFile(path, data, 0o644); err != nil {
+		return "", err
+	}
+	return path, nil
+}
+
+// playCry downloads and plays the cry of pokemon with the audio backend,
+// unless muted.
+func playCry(pokemon Pokemon, legacy bool) tea.Cmd {
+	url := pokemon.CryLatest
+	if legacy {
+		url = pokemon.CryLegacy
+	}
+	if backend, ok := audioBackend.(noopBackend); ok && backend.muted {
+		return func() tea.Msg {
+			return CryMsg{Pokemon: pokemon.Name}
+		}
+	}
+	return func() tea.Msg {
+		path, err := getCry(strings.ToLower(pokemon.Name), url, legacy)
+		if err != nil {
+			return CryMsg{Pokemon: pokemon.Name, Err: err}
+		}
+		return CryMsg{Pokemon: pokemon.Name, Path: path, Err: audioBackend.Play(path)}
+	}
+}
+
+// cryStatus describes the outcome of playing a cry with the audio backend,
+// empty once played.
+func cryStatus(msg CryMsg) string {
+	if msg.Err != nil {
+		return msg.Err.Error()
+	}
+	switch backend := audioBackend.(type) {
+	case noopBackend:
+		if backend.muted {
+			return "cries are muted"
+		}
+		return "no audio player found"
+	case exportBackend:
+		return "cry saved to " + backend.dir
+	}
+	return ""
+}
@@ -0,0 +1,136 @@
+package main
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync/atomic"
+	"testing"
+)
+
+func TestNewAudioBackend(t *testing.T) {
+	// auto picks the first player of audioPlayers in the PATH.
+	bin := t.TempDir()
+	if err := os.WriteFile(filepath.Join(bin, "mpv"), []byte("#!/bin/sh\n"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	t.Setenv("PATH", bin)
+
+	tests := []struct {
+		name string
+		want AudioBackend
+	}{
+		{"none", noopBackend{}},
+		{"export", exportBackend{dir: "cries"}},
+		{"paplay", audioPlayers[2]},
+		{"auto", audioPlayers[1]},
+	}
+	for _, tt := range tests {
+		backend, err := newAudioBackend(tt.name, "cries")
+		if err != nil {
+			t.Errorf("newAudioBackend(%s): %v", tt.name, err)
+			continue
+		}
+		if backend.Name() != tt.want.Name() {
+			t.Errorf("newAudioBackend(%s) = %s, want %s", tt.name, backend.Name(), tt.want.Name())
+		}
+	}
+
+	t.Setenv("PATH", t.TempDir())
+	if backend, _ := newAudioBackend("auto", "cries"); backend != (noopBackend{}) {
+		t.Errorf("newAudioBackend(auto) = %s without players, want none", backend.Name())
+	}
+	if _, err := newAudioBackend("winamp", "cries"); err == nil {
+		t.Errorf("newAudioBackend(winamp) succeeded, want an unknown backend error")
+	}
+}
+
+// serveCries serves the bundled dataset with an OGG body for every cry and
+// returns the number of cries served.
+func serveCries(t *testing.T) *atomic.Int32 {
+	t.Helper()
+	useTempUserDirs(t)
+	api := newBundledFakeAPI(t, 0)
+	cries := &atomic.Int32{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if !strings.HasSuffix(r.URL.Path, ".ogg") {
+			api.ServeHTTP(w, r)
+			return
+		}
+		cries.Add(1)
+		w.Write([]byte("OggS" + r.URL.Path))
+	}))
+	t.Cleanup(server.Close)
+	target, _ := url.Parse(server.URL)
+	usePokeAPI(t, redirectTransport{target: target}, server.URL+"/api/v2/")
+	return cries
+}
+
+func TestGetCryCaches(t *testing.T) {
+	cries := serveCries(t)
+	pokemon, err := getPokemon("pikachu")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	path, err := getCry("pikachu", pokemon.CryLatest, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// The second cry is read from the file, not the response cache.
+	clearResponseCache()
+	again, err := getCry("pikachu", pokemon.CryLatest, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if path != again || cries.Load() != 1 {
+		t.Errorf("cry at %s then %s after %d downloads, want the same file downloaded once", path, again, cries.Load())
+	}
+	if data, err := os.ReadFile(path); err != nil || !strings.HasSuffix(string(data), "/25.ogg") {
+		t.Errorf("cry file %q (%v), want the latest cry of pikachu", data, err)
+	}
+
+	if _, err := getCry("pikachu", pokemon.CryLegacy, true); err != nil {
+		t.Fatal(err)
+	}
+	if cries.Load() != 2 {
+		t.Errorf("%d downloads after the legacy cry, want 2", cries.Load())
+	}
+}
+
+func TestPlayCryStatus(t *testing.T) {
+	cries := serveCries(t)
+	pokemon, err := getPokemon("pikachu")
+	if err != nil {
+		t.Fatal(err)
+	}
+	previous := audioBackend
+	t.Cleanup(func() { audioBackend = previous })
+
+	dir := t.TempDir()
+	tests := []struct {
+		backend AudioBackend
+		want    string
+		cries   int32
+	}{
+		{noopBackend{muted: true}, "cries are muted", 0},
+		{noopBackend{}, "no audio player found", 1},
+		{exportBackend{dir: dir}, "cry saved to " + dir, 1},
+	}
+	for _, tt := range tests {
+		audioBackend = tt.backend
+		msg := playCry(pokemon, false)().(CryMsg)
+		if status := cryStatus(msg); status != tt.want {
+			t.Errorf("status %q with the %s backend, want %q", status, tt.backend.Name(), tt.want)
+		}
+		if cries.Load() != tt.cries {
+			t.Errorf("%d cries downloaded with the %s backend, want %d", cries.Load(), tt.backend.Name(), tt.cries)
+		}
+	}
+	if _, err := os.Stat(filepath.Join(dir, "pikachu-latest.ogg")); err != nil {
+		t.Errorf("cry not exported: %v", err)
+	}
+}
@@ -0,0 +1,103 @@
+package main
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+func getBerryDetail(name string) (BrowserDetail, error) {
+	var berryResponse BerryResponse
+	err := fetchJSON(POKEAPI_URL+"berry/"+name, "berry", &berryResponse)
+	if err != nil {
+		return BrowserDetail{}, err
+	}
+
+	flavors := []string{}
+	links := []BrowserLink{
+		{Label: "firmness: " + berryResponse.Firmness.Name, Route: "Berries", Resource: "berry-firmness", Name: berryResponse.Firmness.Name},
+	}
+	for _, flavor := range berryResponse.Flavors {
+		flavors = append(flavors, fmt.Sprintf("%s %d", flavor.Flavor.Name, flavor.Potency))
+		if flavor.Potency > 0 {
+			links = append(links, BrowserLink{
+				Label:    fmt.Sprintf("flavor: %s (%d)", flavor.Flavor.Name, flavor.Potency),
+				Route:    "Berries",
+				Resource: "berry-flavor",
+				Name:     flavor.Flavor.Name,
+			})
+		}
+	}
+	links = append(links, BrowserLink{Label: "item: " + berryResponse.Item.Name, Route: "Items", Resource: "item", Name: berryResponse.Item.Name})
+
+	return BrowserDetail{
+		Name: berryResponse.Name,
+		Body: fmt.Sprintf("Name: %s\nFirmness: %s\nFlavors: %s\nSize: %dmm\nSmoothness: %d\nGrowth time: %dh per stage\nMax harvest: %d\nSoil dryness: %d\nNatural gift: %s %d",
+			berryResponse.Name,
+			berryResponse.Firmness.Name,
+			strings.Join(flavors, ", "),
+			berryResponse.Size,
+			berryResponse.Smoothness,
+			berryResponse.GrowthTime,
+			berryResponse.MaxHarvest,
+			berryResponse.SoilDryness,
+			berryResponse.NaturalGiftType.Name,
+			berryResponse.NaturalGiftPower,
+		),
+		LinksTitle: "Related",
+		Links:      links,
+	}, nil
+}
+
+func getBerryFlavorDetail(name string) (BrowserDetail, error) {
+	var flavorResponse BerryFlavorResponse
+	err := fetchJSON(POKEAPI_URL+"berry-flavor/"+name, "berry flavor", &flavorResponse)
+	if err != nil {
+		return BrowserDetail{}, err
+	}
+
+	berries := flavorResponse.Berries
+	sort.SliceStable(berries, func(i, j int) bool {
+		return berries[i].Potency > berries[j].Potency
+	})
+
+	links := []BrowserLink{}
+	for _, berry := range berries {
+		if berry.Potency == 0 {
+			continue
+		}
+		links = append(links, BrowserLink{
+			Label:    fmt.Sprintf("%s (%d)", berry.Berry.Name, berry.Potency),
+			Route:    "Berries",
+			Resource: "berry",
+			Name:     berry.Berry.Name,
+		})
+	}
+
+	return BrowserDetail{
+		Name:       flavorResponse.Name,
+		Body:       fmt.Sprintf("Flavor: %s\nContest type: %s", flavorResponse.Name, flavorResponse.ContestType.Name),
+		LinksTitle: fmt.Sprintf("%d %s berries by potency", len(links), flavorResponse.Name),
+		Links:      links,
+	}, nil
+}
+
+func getBerryFirmnessDetail(name string) (BrowserDetail, error) {
+	var firmnessResponse BerryFirmnessResponse
+	err := fetchJSON(POKEAPI_URL+"berry-firmness/"+name, "berry firmness", &firmnessResponse)
+	if err != nil {
+		return BrowserDetail{}, err
+	}
+
+	links := []BrowserLink{}
+	for _, berry := range firmnessResponse.Berries {
+		links = append(links, BrowserLink{Label: berry.Name, Route: "Berries", Resource: "berry", Name: berry.Name})
+	}
+
+	return BrowserDetail{
+		Name:       firmnessResponse.Name,
+		Body:       fmt.Sprintf("Firmness: %s", firmnessResponse.Name),
+		LinksTitle: fmt.Sprintf("%d %s berries", len(links), firmnessResponse.Name),
+		Links:      links,
+	}, nil
+}
@@ -0,0 +1,379 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/key"
+	"github.com/charmbracelet/bubbles/list"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// BrowserModel is a paginated list of a PokeAPI resource with a detail pane
+// for the selected entry, used by the Moves, Items and Berries routes.
+type BrowserModel struct {
+	Route    string
+	Resource string
+	List     list.Model
+	// Page is the page listed, Requested the page fetched last.
+	Page      int
+	Requested int
+	Count     int
+	Detail    BrowserDetail
+	// LoadingDetail names the entry whose detail is fetched, the detail
+	// shown is kept if it fails.
+	LoadingDetail string
+	// DetailFocused moves the keys from the list to the links of the
+	// detail pane.
+	DetailFocused bool
+	LinkCursor    int
+	// Loading is set while a page of the list is fetched.
+	Loading   bool
+	isFocused bool
+	height    int
+	spinner   string
+}
+
+type BrowserDetail struct {
+	Name       string
+	Body       string
+	LinksTitle string
+	Links      []BrowserLink
+}
+
+// BrowserLink cross links a detail to another resource, Route is the route
+// the resource is displayed in and Resource the PokeAPI endpoint within it.
+type BrowserLink struct {
+	Label    string
+	Route    string
+	Resource string
+	Name     string
+}
+
+type BrowserItem struct {
+	title string
+}
+
+func (i BrowserItem) Title() string       { return i.title }
+func (i BrowserItem) Description() string { return "" }
+func (i BrowserItem) FilterValue() string { return i.title }
+
+// BrowserListMsg is the page Page of the list of Route.
+type BrowserListMsg struct {
+	Route string
+	Page  int
+	List  PokemonList
+	Err   error
+}
+
+type BrowserDetailMsg struct {
+	Route    string
+	Resource string
+	Name     string
+	Detail   BrowserDetail
+	Err      error
+}
+
+type BrowserLinkMsg struct {
+	Link BrowserLink
+}
+
+// newBrowserDelegate draws the entries one title per line.
+func newBrowserDelegate() list.DefaultDelegate {
+	delegate := list.NewDefaultDelegate()
+	delegate.ShowDescription = false
+	delegate.SetSpacing(0)
+	return delegate
+}
+
+func NewBrowserModel(route string, resource string) BrowserModel {
+	delegate := newBrowserDelegate()
+
+	l := list.New([]list.Item{}, delegate, 0, 0)
+	l.SetShowStatusBar(false)
+	l.SetShowTitle(false)
+	l.DisableQuitKeybindings()
+	// The keys are listed by the help overlay, see keys.go.
+	l.SetShowHelp(false)
+
+	return BrowserModel{
+		Route:    route,
+		Resource: resource,
+		List:     l,
+	}
+}
+
+// fetchList fetches page of the list, the listed page is kept until it is
+// fetched.
+func (b BrowserModel) fetchList(page int) (BrowserModel, tea.Cmd) {
+	b.Requested = page
+	b.Loading = true
+	route, resource := b.Route, b.Resource
+	return b, func() tea.Msg {
+		l, err := getResourceList(resource, page)
+		return BrowserListMsg{Route: route, Page: page, List: l, Err: err}
+	}
+}
+
+func (b BrowserModel) fetchDetail(resource string, name string) (BrowserModel, tea.Cmd) {
+	b.LoadingDetail = name
+	route := b.Route
+	return b, func() tea.Msg {
+		detail, err := getBrowserDetail(resource, name)
+		return BrowserDetailMsg{Route: route, Resource: resource, Name: name, Detail: detail, Err: err}
+	}
+}
+
+// getResourceList fetches a page of a PokeAPI resource list, which share the
+// shape of the pokemon list.
+func getResourceList(resource string, page int) (PokemonList, error) {
+	offset := 20 * page
+	var listResponse PokemonListResponse
+	err := fetchJSON(fmt.Sprintf("%s%s/?offset=%d&limit=20", POKEAPI_URL, resource, offset), resource+" list", &listResponse)
+	if err != nil {
+		return PokemonList{}, err
+	}
+
+	return formatPokemonList(listResponse), nil
+}
+
+func getBrowserDetail(resource string, name string) (BrowserDetail, error) {
+	switch resource {
+	case "move":
+		move, err := getMove(name)
+		if err != nil {
+			return BrowserDetail{}, err
+		}
+		return moveDetail(move), nil
+	case "item":
+		msg := getItemWithSprite(name)
+		if msg.Err != nil {
+			return BrowserDetail{}, msg.Err
+		}
+		return itemDetail(msg), nil
+	case "berry":
+		return getBerryDetail(name)
+	case "berry-flavor":
+		return getBerryFlavorDetail(name)
+	case "berry-firmness":
+		return getBerryFirmnessDetail(name)
+	}
+	return BrowserDetail{}, fmt.Errorf("unknown resource %s", resource)
+}
+
+func (b BrowserModel) Update(msg tea.Msg) (BrowserModel, tea.Cmd) {
+	var cmd tea.Cmd
+
+	switch msg := msg.(type) {
+	case BrowserListMsg:
+		// A page fetched before paging again is dropped.
+		if msg.Page != b.Requested {
+			return b, nil
+		}
+		b.Loading = false
+		if msg.Err != nil {
+			// The error is displayed as a toast, keeping the listed page.
+			b.Requested = b.Page
+			return b, nil
+		}
+		b.Page = msg.Page
+		b.Count = msg.List.Count
+		items := make([]list.Item, len(msg.List.Results))
+		for i, name := range msg.List.Results {
+			items[i] = BrowserItem{title: name}
+		}
+		cmd = b.List.SetItems(items)
+		b.List.ResetSelected()
+		return b, cmd
+
+	case BrowserDetailMsg:
+		if msg.Name != b.LoadingDetail {
+			return b, nil
+		}
+		b.LoadingDetail = ""
+		if msg.Err != nil {
+			// The error is displayed as a toast, keeping the detail shown.
+			b.DetailFocused = b.DetailFocused && b.Detail.Name != ""
+			return b, nil
+		}
+		b.Detail = msg.Detail
+		b.Detail.Name = msg.Name
+		b.LinkCursor = 0
+		return b, nil
+
+	case tea.KeyMsg:
+		if b.DetailFocused {
+			return b.handleDetailKey(msg)
+		}
+		if b.List.SettingFilter() {
+			break
+		}
+
+		k := b.keys(false)
+		switch {
+		case key.Matches(msg, k.PrevPage):
+			if b.Requested > 0 {
+				return b.fetchList(b.Requested - 1)
+			}
+			return b, nil
+		case key.Matches(msg, k.NextPage):
+			if (b.Requested+1)*20 < b.Count {
+				return b.fetchList(b.Requested + 1)
+			}
+			return b, nil
+		case key.Matches(msg, k.Show):
+			selectedItem := b.List.SelectedItem()
+			if selectedItem == nil {
+				return b, nil
+			}
+			b.DetailFocused = true
+			return b.fetchDetail(b.Resource, selectedItem.FilterValue())
+		}
+	}
+
+	b.List, cmd = b.List.Update(msg)
+	return b, cmd
+}
+
+func (b BrowserModel) handleDetailKey(msg tea.KeyMsg) (BrowserModel, tea.Cmd) {
+	k := b.keys(false)
+	switch {
+	case key.Matches(msg, k.Back):
+		b.DetailFocused = false
+	case key.Matches(msg, k.LinkUp):
+		if b.LinkCursor > 0 {
+			b.LinkCursor--
+		}
+	case key.Matches(msg, k.LinkDown):
+		if b.LinkCursor < len(b.Detail.Links)-1 {
+			b.LinkCursor++
+		}
+	case key.Matches(msg, k.Follow):
+		if len(b.Detail.Links) == 0 {
+			break
+		}
+		link := b.Detail.Links[b.LinkCursor]
+		return b, func() tea.Msg {
+			return BrowserLinkMsg{Link: link}
+		}
+	}
+	return b, nil
+}
+
+// handleMouse handles mouse presses on the browser drawn in l, clicking an
+// entry shows its details, clicking the detail pane focuses it and the wheel
+// scrolls what is under the pointer.
+func (b BrowserModel) handleMouse(msg tea.MouseMsg, l BrowserLayout) (BrowserModel, tea.Cmd) {
+	key, isWheel := wheelKey(msg)
+	if !isWheel && msg.Button != tea.MouseButtonLeft {
+		return b, nil
+	}
+
+	if l.Detail.Contains(msg.X, msg.Y) {
+		if b.Detail.Name == "" {
+			return b, nil
+		}
+		b.DetailFocused = true
+		if isWheel {
+			return b.handleDetailKey(key)
+		}
+		return b, nil
+	}
+
+	b.DetailFocused = false
+	if isWheel {
+		var cmd tea.Cmd
+		b.List, cmd = b.List.Update(key)
+		return b, cmd
+	}
+	index, ok := listItemAt(b.List, msg.Y-l.ListBody.Y, newBrowserDelegate())
+	if !ok {
+		return b, nil
+	}
+	b.List.Select(index)
+	return b.Update(tea.KeyMsg{Type: tea.KeyEnter})
+}
+
+// detailName returns the name of the entry whose detail is shown or fetched.
+func (b BrowserModel) detailName() string {
+	if b.LoadingDetail != "" {
+		return b.LoadingDetail
+	}
+	return b.Detail.Name
+}
+
+func (b BrowserModel) detailView(height int) string {
+	if b.LoadingDetail != "" {
+		return loadingView(b.spinner)
+	}
+	if b.Detail.Name == "" {
+		return "Select a " + b.Resource + " and press enter"
+	}
+
+	lines := strings.Split(b.Detail.Body, "\n")
+	cursorLine := len(lines)
+	if len(b.Detail.Links) > 0 {
+		lines = append(lines, "", b.Detail.LinksTitle)
+		cursorLine = len(lines) + b.LinkCursor
+		for i, link := range b.Detail.Links {
+			if b.DetailFocused && i == b.LinkCursor {
+				lines = append(lines, "> "+link.Label)
+			} else {
+				lines = append(lines, "  "+link.Label)
+			}
+		}
+	}
+
+	start := 0
+	if height > 0 && cursorLine >= height {
+		start = cursorLine - height + 1
+	}
+	end := len(lines)
+	if height > 0 {
+		end = min(start+height, len(lines))
+	}
+	return strings.Join(lines[start:end], "\n")
+}
+
+// View renders the list and the detail pane side by side within width and
+// height, including their borders.
+func (b BrowserModel) View(styles *Styles, width int, height int, focused bool) string {
+	borderStyle, headerStyle, bodyStyle := styles.UnfocusedBorderedStyle, styles.DisplayHeaderUnfocusedStyle, styles.DisplayBodyUnfocusedStyle
+	if focused {
+		borderStyle, headerStyle, bodyStyle = styles.FocusedBorderedStyle, styles.DisplayHeaderFocusedStyle, styles.DisplayBodyFocusedStyle
+	}
+
+	listWidth := width * 2 / 5
+	detailWidth := width - listWidth - 4
+	b.List.SetHeight(height - 2)
+	b.List.SetWidth(listWidth)
+
+	page := fmt.Sprintf("%s - page %d", b.Route, b.Page+1)
+	if b.Count > 0 {
+		page = fmt.Sprintf("%s - page %d of %d", b.Route, b.Page+1, (b.Count+19)/20)
+	}
+	if b.Loading {
+		page += " " + b.spinner
+	}
+
+	return lipgloss.JoinHorizontal(
+		lipgloss.Top,
+		/* LIST */
+		borderStyle.Height(height).Width(listWidth).Render(
+			lipgloss.JoinVertical(
+				lipgloss.Left,
+				headerStyle.Render(page),
+				b.List.View(),
+			),
+		),
+		/* DETAIL */
+		borderStyle.Height(height).Width(detailWidth).Render(
+			lipgloss.JoinVertical(
+				lipgloss.Left,
+				headerStyle.Render(b.detailName()),
+				bodyStyle.Render(b.detailView(height-3)),
+			),
+		),
+	)
+}
@@ -0,0 +1,365 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/key"
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// DamageCalcModel is the damage calculator route, the attacker uses the move
+// on the defender, both with max IVs.
+type DamageCalcModel struct {
+	Attacker  DamageCalcSide
+	Defender  DamageCalcSide
+	MoveInput textinput.Model
+	Move      Move
+	Critical  bool
+	Weather   int
+	Cursor    int
+	Editing   bool
+	isFocused bool
+}
+
+type DamageCalcSide struct {
+	Input   textinput.Model
+	Pokemon Pokemon
+	Level   int
+	Nature  int
+	EVs     [6]int
+}
+
+var weathers = []string{"", "sun", "rain"}
+
+type DamageCalcPokemonMsg struct {
+	Attacker bool
+	Pokemon  Pokemon
+	Err      error
+}
+
+type DamageCalcMoveMsg struct {
+	Move Move
+	Err  error
+}
+
+// damageCalcField is a row of the calculator form, stat is the index in
+// statNames of the EVs the row edits.
+type damageCalcField struct {
+	label    string
+	attacker bool
+	kind     string
+	stat     int
+}
+
+var damageCalcFields = []damageCalcField{
+	{label: "Attacker", attacker: true, kind: "pokemon"},
+	{label: "Level", attacker: true, kind: "level"},
+	{label: "Nature", attacker: true, kind: "nature"},
+	{label: "Atk EVs", attacker: true, kind: "ev", stat: 1},
+	{label: "SpA EVs", attacker: true, kind: "ev", stat: 3},
+	{label: "Defender", kind: "pokemon"},
+	{label: "Level", kind: "level"},
+	{label: "Nature", kind: "nature"},
+	{label: "HP EVs", kind: "ev", stat: 0},
+	{label: "Def EVs", kind: "ev", stat: 2},
+	{label: "SpD EVs", kind: "ev", stat: 4},
+	{label: "Move", kind: "move"},
+	{label: "Critical", kind: "critical"},
+	{label: "Weather", kind: "weather"},
+}
+
+func newDamageCalcSide(placeholder string) DamageCalcSide {
+	ti := textinput.New()
+	ti.Placeholder = placeholder
+	ti.CharLimit = 64
+	return DamageCalcSide{Input: ti, Level: 50}
+}
+
+func NewDamageCalcModel() DamageCalcModel {
+	ti := textinput.New()
+	ti.Placeholder = "Move name"
+	ti.CharLimit = 64
+
+	return DamageCalcModel{
+		Attacker:  newDamageCalcSide("Attacking pokemon"),
+		Defender:  newDamageCalcSide("Defending pokemon"),
+		MoveInput: ti,
+	}
+}
+
+func (d *DamageCalcModel) side(attacker bool) *DamageCalcSide {
+	if attacker {
+		return &d.Attacker
+	}
+	return &d.Defender
+}
+
+// input returns the text input of the field under the cursor, nil if the
+// field is not a text field.
+func (d *DamageCalcModel) input() *textinput.Model {
+	field := damageCalcFields[d.Cursor]
+	switch field.kind {
+	case "pokemon":
+		return &d.side(field.attacker).Input
+	case "move":
+		return &d.MoveInput
+	}
+	return nil
+}
+
+func (d DamageCalcModel) Update(msg tea.Msg) (DamageCalcModel, tea.Cmd) {
+	var cmd tea.Cmd
+
+	switch msg := msg.(type) {
+	case DamageCalcPokemonMsg:
+		// A failed fetch keeps the chosen pokemon, its error is shown as a
+		// toast.
+		if msg.Err == nil {
+			d.side(msg.Attacker).Pokemon = msg.Pokemon
+		}
+		return d, nil
+
+	case DamageCalcMoveMsg:
+		if msg.Err == nil {
+			d.Move = msg.Move
+		}
+		return d, nil
+
+	case tea.KeyMsg:
+		if d.Editing {
+			return d.handleEditingKey(msg)
+		}
+		return d.handleKey(msg)
+	}
+
+	if input := d.input(); input != nil {
+		*input, cmd = input.Update(msg)
+	}
+	return d, cmd
+}
+
+func (d DamageCalcModel) handleEditingKey(msg tea.KeyMsg) (DamageCalcModel, tea.Cmd) {
+	var cmd tea.Cmd
+	input := d.input()
+
+	k := d.keys(false)
+	switch {
+	case key.Matches(msg, k.Cancel):
+		d.Editing = false
+		input.Blur()
+		return d, nil
+	case key.Matches(msg, k.Confirm):
+		d.Editing = false
+		input.Blur()
+		name := strings.ToLower(strings.TrimSpace(input.Value()))
+		if name == "" {
+			return d, nil
+		}
+		field := damageCalcFields[d.Cursor]
+		if field.kind == "move" {
+			return d, func() tea.Msg {
+				move, err := getMove(strings.ReplaceAll(name, " ", "-"))
+				return DamageCalcMoveMsg{Move: move, Err: err}
+			}
+		}
+		return d, func() tea.Msg {
+			pokemon, err := searchPokemon(name)
+			return DamageCalcPokemonMsg{Attacker: field.attacker, Pokemon: pokemon, Err: err}
+		}
+	}
+
+	*input, cmd = input.Update(msg)
+	return d, cmd
+}
+
+func (d DamageCalcModel) handleKey(msg tea.KeyMsg) (DamageCalcModel, tea.Cmd) {
+	field := damageCalcFields[d.Cursor]
+	side := d.side(field.attacker)
+
+	k := d.keys(false)
+	step, big := k.Adjust.step(msg)
+	switch {
+	case key.Matches(msg, k.Up):
+		if d.Cursor > 0 {
+			d.Cursor--
+		}
+	case key.Matches(msg, k.Down):
+		if d.Cursor < len(damageCalcFields)-1 {
+			d.Cursor++
+		}
+	case key.Matches(msg, k.Edit):
+		if field.kind == "critical" {
+			d.Critical = !d.Critical
+		}
+		if input := d.input(); input != nil {
+			d.Editing = true
+			input.SetValue("")
+			return d, input.Focus()
+		}
+	}
+	if step == 0 {
+		return d, nil
+	}
+
+	switch field.kind {
+	case "level":
+		if big {
+			step *= 10
+		}
+		side.Level = max(1, min(100, side.Level+step))
+	case "nature":
+		side.Nature = (side.Nature + len(natures) + step) % len(natures)
+	case "ev":
+		ev := side.EVs[field.stat] + step*4
+		if big {
+			ev = side.EVs[field.stat] + step*maxEV
+		}
+		ev = max(0, min(maxEV, ev))
+		total := 0
+		for _, e := range side.EVs {
+			total += e
+		}
+		side.EVs[field.stat] = min(ev, maxEVTotal-total+side.EVs[field.stat])
+	case "critical":
+		d.Critical = !d.Critical
+	case "weather":
+		d.Weather = (d.Weather + len(weathers) + step) % len(weathers)
+	}
+	return d, nil
+}
+
+// stat computes the actual stat of a side with max IVs.
+func (s DamageCalcSide) stat(stat string) int {
+	for i, name := range statNames {
+		if name != stat {
+			continue
+		}
+		for _, pokemonStat := range s.Pokemon.Stats {
+			if pokemonStat.Name == stat {
+				return calcStat(stat, pokemonStat.Base, maxIV, s.EVs[i], s.Level, natureModifier(natures[s.Nature], stat))
+			}
+		}
+	}
+	return 0
+}
+
+func (d DamageCalcModel) fieldValue(i int) string {
+	field := damageCalcFields[i]
+	side := d.side(field.attacker)
+
+	switch field.kind {
+	case "pokemon":
+		if d.Editing && d.Cursor == i {
+			return side.Input.View()
+		}
+		if side.Pokemon.Name == "" {
+			return "enter to choose"
+		}
+		return fmt.Sprintf("%s (%s)", side.Pokemon.Name, strings.Join(side.Pokemon.Types, ", "))
+	case "move":
+		if d.Editing && d.Cursor == i {
+			return d.MoveInput.View()
+		}
+		if d.Move.Name == "" {
+			return "enter to choose"
+		}
+		return fmt.Sprintf("%s (%s, %s, %d)", d.Move.Name, d.Move.Type, d.Move.DamageClass, d.Move.Power)
+	case "level":
+		return fmt.Sprintf("%d", side.Level)
+	case "nature":
+		return natures[side.Nature].Name
+	case "ev":
+		return fmt.Sprintf("%d", side.EVs[field.stat])
+	case "critical":
+		if d.Critical {
+			return "yes"
+		}
+		return "no"
+	case "weather":
+		if weathers[d.Weather] == "" {
+			return "none"
+		}
+		return weathers[d.Weather]
+	}
+	return ""
+}
+
+func (d DamageCalcModel) resultView() string {
+	if d.Attacker.Pokemon.Name == "" || d.Defender.Pokemon.Name == "" || d.Move.Name == "" {
+		return "Choose an attacker, a defender and a move"
+	}
+	if d.Move.DamageClass == "status" || d.Move.Power == 0 {
+		return d.Move.Name + " deals no direct damage"
+	}
+
+	attackStat, defenseStat := "attack", "defense"
+	if d.Move.DamageClass == "special" {
+		attackStat, defenseStat = "special-attack", "special-defense"
+	}
+	hp := d.Defender.stat("hp")
+	result := calcDamage(DamageInput{
+		Level:         d.Attacker.Level,
+		Power:         d.Move.Power,
+		Attack:        d.Attacker.stat(attackStat),
+		Defense:       d.Defender.stat(defenseStat),
+		MoveType:      d.Move.Type,
+		AttackerTypes: d.Attacker.Pokemon.Types,
+		DefenderTypes: d.Defender.Pokemon.Types,
+		Critical:      d.Critical,
+		Weather:       weathers[d.Weather],
+	})
+
+	rolls := []string{}
+	for _, roll := range result.Rolls {
+		rolls = append(rolls, fmt.Sprintf("%d", roll))
+	}
+
+	return strings.Join([]string{
+		fmt.Sprintf("%s %d vs %s %d HP / %d %s",
+			attackStat, d.Attacker.stat(attackStat), d.Defender.Pokemon.Name, hp, d.Defender.stat(defenseStat), defenseStat),
+		fmt.Sprintf("Damage: %d-%d (%.1f%% - %.1f%%)",
+			result.Min(), result.Max(), float64(result.Min())*100/float64(hp), float64(result.Max())*100/float64(hp)),
+		fmt.Sprintf("Type effectiveness: x%g", result.Effectiveness),
+		koChanceView(result.Rolls, hp),
+		"Rolls: " + strings.Join(rolls, ", "),
+	}, "\n")
+}
+
+// body renders the lines of the calculator that fit in width and height,
+// scrolled to keep the cursor visible.
+func (d DamageCalcModel) body(width int, height int) string {
+	lines := []string{"Damage calculator (up/down - select, left/right - adjust, enter - edit)", ""}
+	cursorLine := 0
+	for i, field := range damageCalcFields {
+		if (i > 0 && field.kind == "pokemon") || field.kind == "move" {
+			lines = append(lines, "")
+		}
+		cursor := "  "
+		if i == d.Cursor {
+			cursor = "> "
+			cursorLine = len(lines)
+		}
+		lines = append(lines, fmt.Sprintf("%s%-10s%s", cursor, field.label, d.fieldValue(i)))
+	}
+	lines = append(lines, "", d.resultView())
+	return scrollLines(lines, cursorLine, width, height)
+}
+
+// View renders the calculator within width and height, including borders.
+func (d DamageCalcModel) View(styles *Styles, width int, height int, focused bool) string {
+	borderStyle, headerStyle, bodyStyle := styles.UnfocusedBorderedStyle, styles.DisplayHeaderUnfocusedStyle, styles.DisplayBodyUnfocusedStyle
+	if focused {
+		borderStyle, headerStyle, bodyStyle = styles.FocusedBorderedStyle, styles.DisplayHeaderFocusedStyle, styles.DisplayBodyFocusedStyle
+	}
+
+	return borderStyle.Height(height).Width(width - 2).Render(
+		lipgloss.JoinVertical(
+			lipgloss.Left,
+			headerStyle.Render("Damage Calc"),
+			bodyStyle.Render(d.body(width-2-bodyStyle.GetHorizontalFrameSize(), height-1-bodyStyle.GetVerticalFrameSize())),
+		),
+	)
+}
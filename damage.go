@@ -0,0 +1,121 @@
+package main
+
+import "fmt"
+
+// DamageInput holds everything the damage formula depends on, Attack and
+// Defense are the actual stats used by the move category.
+type DamageInput struct {
+	Level         int
+	Power         int
+	Attack        int
+	Defense       int
+	MoveType      string
+	AttackerTypes []string
+	DefenderTypes []string
+	Critical      bool
+	// Weather is one of sun, rain or empty for no weather.
+	Weather string
+}
+
+type DamageResult struct {
+	Rolls         [16]int
+	Effectiveness float64
+}
+
+func (r DamageResult) Min() int { return r.Rolls[0] }
+func (r DamageResult) Max() int { return r.Rolls[15] }
+
+// applyModifier multiplies value by modifier/4096 rounding halves down, like
+// the games chain their damage modifiers.
+func applyModifier(value int, modifier int) int {
+	return (value*modifier + 2047) / 4096
+}
+
+// calcDamage computes the 16 possible damage rolls of a move with the formula
+// used since generation V.
+func calcDamage(input DamageInput) DamageResult {
+	result := DamageResult{Effectiveness: damageMultiplier(input.MoveType, input.DefenderTypes, 0)}
+	if input.Power == 0 || input.Defense == 0 || result.Effectiveness == 0 {
+		return result
+	}
+
+	base := (2*input.Level/5+2)*input.Power*input.Attack/input.Defense/50 + 2
+
+	switch {
+	case input.Weather == "sun" && input.MoveType == "fire", input.Weather == "rain" && input.MoveType == "water":
+		base = applyModifier(base, 6144)
+	case input.Weather == "sun" && input.MoveType == "water", input.Weather == "rain" && input.MoveType == "fire":
+		base = applyModifier(base, 2048)
+	}
+
+	if input.Critical {
+		base = base * 3 / 2
+	}
+
+	stab := false
+	for _, t := range input.AttackerTypes {
+		if t == input.MoveType {
+			stab = true
+		}
+	}
+
+	for i := range result.Rolls {
+		damage := base * (85 + i) / 100
+		if stab {
+			damage = applyModifier(damage, 6144)
+		}
+		damage = int(float64(damage) * result.Effectiveness)
+		result.Rolls[i] = max(damage, 1)
+	}
+	return result
+}
+
+// koChance returns the number of identical hits needed to KO a pokemon with
+// hp and the probability of doing it in that many hits, hits is 0 if it takes
+// more than maxHits.
+func koChance(rolls [16]int, hp int, maxHits int) (int, float64) {
+	// sums maps each total damage to the number of roll combinations
+	// reaching it.
+	sums := map[int]int{0: 1}
+	combinations := 1
+	for hits := 1; hits <= maxHits; hits++ {
+		next := map[int]int{}
+		for sum, count := range sums {
+			for _, roll := range rolls {
+				next[sum+roll] += count
+			}
+		}
+		sums = next
+		combinations *= len(rolls)
+
+		kos := 0
+		for sum, count := range sums {
+			if sum >= hp {
+				kos += count
+			}
+		}
+		if kos > 0 {
+			return hits, float64(kos) / float64(combinations)
+		}
+	}
+	return 0, 0
+}
+
+func koChanceView(rolls [16]int, hp int) string {
+	if rolls[15] == 0 {
+		return "no damage"
+	}
+
+	hits, chance := koChance(rolls, hp, 4)
+	switch {
+	case hits == 0:
+		return "not a KO in 4 hits"
+	case chance == 1 && hits == 1:
+		return "guaranteed OHKO"
+	case chance == 1:
+		return fmt.Sprintf("guaranteed %dHKO", hits)
+	case hits == 1:
+		return fmt.Sprintf("%.1f%% chance to OHKO", chance*100)
+	}
+	return fmt.Sprintf("%.1f%% chance to %dHKO", chance*100, hits)
+}
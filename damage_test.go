@@ -0,0 +1,125 @@
+package main
+
+import "testing"
+
+func TestCalcDamage(t *testing.T) {
+	tests := []struct {
+		name  string
+		input DamageInput
+		min   int
+		max   int
+	}{
+		{
+			// The worked example of the damage formula on Bulbapedia.
+			name: "level 75 glaceon ice fang on garchomp",
+			input: DamageInput{
+				Level: 75, Power: 65, Attack: 123, Defense: 163,
+				MoveType: "ice", AttackerTypes: []string{"ice"}, DefenderTypes: []string{"dragon", "ground"},
+			},
+			min: 168,
+			max: 196,
+		},
+		{
+			name: "critical hit",
+			input: DamageInput{
+				Level: 75, Power: 65, Attack: 123, Defense: 163, Critical: true,
+				MoveType: "ice", AttackerTypes: []string{"ice"}, DefenderTypes: []string{"dragon", "ground"},
+			},
+			min: 244,
+			max: 292,
+		},
+		{
+			name: "neutral without stab",
+			input: DamageInput{
+				Level: 50, Power: 80, Attack: 100, Defense: 100,
+				MoveType: "normal", AttackerTypes: []string{"fire"}, DefenderTypes: []string{"water"},
+			},
+			min: 31,
+			max: 37,
+		},
+		{
+			name: "resisted with stab",
+			input: DamageInput{
+				Level: 50, Power: 80, Attack: 100, Defense: 100,
+				MoveType: "fire", AttackerTypes: []string{"fire"}, DefenderTypes: []string{"water"},
+			},
+			min: 23,
+			max: 27,
+		},
+		{
+			name: "immune",
+			input: DamageInput{
+				Level: 50, Power: 80, Attack: 100, Defense: 100,
+				MoveType: "normal", AttackerTypes: []string{"normal"}, DefenderTypes: []string{"ghost"},
+			},
+			min: 0,
+			max: 0,
+		},
+		{
+			name: "rain boosted water move",
+			input: DamageInput{
+				Level: 50, Power: 80, Attack: 100, Defense: 100, Weather: "rain",
+				MoveType: "water", AttackerTypes: []string{"grass"}, DefenderTypes: []string{"normal"},
+			},
+			min: 46,
+			max: 55,
+		},
+		{
+			name: "sun weakened water move",
+			input: DamageInput{
+				Level: 50, Power: 80, Attack: 100, Defense: 100, Weather: "sun",
+				MoveType: "water", AttackerTypes: []string{"grass"}, DefenderTypes: []string{"normal"},
+			},
+			min: 15,
+			max: 18,
+		},
+		{
+			name: "status move",
+			input: DamageInput{
+				Level: 50, Power: 0, Attack: 100, Defense: 100,
+				MoveType: "normal", AttackerTypes: []string{"normal"}, DefenderTypes: []string{"normal"},
+			},
+			min: 0,
+			max: 0,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := calcDamage(tt.input)
+			if result.Min() != tt.min || result.Max() != tt.max {
+				t.Errorf("calcDamage() = %d-%d, want %d-%d", result.Min(), result.Max(), tt.min, tt.max)
+			}
+		})
+	}
+}
+
+func TestKOChance(t *testing.T) {
+	// The rolls of a 37 damage hit.
+	rolls := calcDamage(DamageInput{
+		Level: 50, Power: 80, Attack: 100, Defense: 100,
+		MoveType: "normal", AttackerTypes: []string{"fire"}, DefenderTypes: []string{"water"},
+	}).Rolls
+
+	tests := []struct {
+		hp     int
+		hits   int
+		chance float64
+		view   string
+	}{
+		{hp: 30, hits: 1, chance: 1, view: "guaranteed OHKO"},
+		{hp: 35, hits: 1, chance: 6.0 / 16, view: "37.5% chance to OHKO"},
+		{hp: 62, hits: 2, chance: 1, view: "guaranteed 2HKO"},
+		{hp: 200, hits: 0, chance: 0, view: "not a KO in 4 hits"},
+	}
+
+	for _, tt := range tests {
+		hits, chance := koChance(rolls, tt.hp, 4)
+		if hits != tt.hits || chance != tt.chance {
+			t.Errorf("koChance(%d) = %d, %f, want %d, %f", tt.hp, hits, chance, tt.hits, tt.chance)
+		}
+		if view := koChanceView(rolls, tt.hp); view != tt.view {
+			t.Errorf("koChanceView(%d) = %q, want %q", tt.hp, view, tt.view)
+		}
+	}
+}
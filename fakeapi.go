@@ -0,0 +1,211 @@
+package main
+
+import (
+	"embed"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io/fs"
+	"net"
+	"net/http"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// fakeDataset is the dataset served by serve-fake, one JSON array per
+// resource named after its endpoint, linking to each other with PokeAPI urls.
+//
+//go:embed fakeapi
+var fakeDataset embed.FS
+
+// FakeResource is an entry of the dataset, looked up by name or id.
+type FakeResource struct {
+	ID   int
+	Name string
+	Body []byte
+}
+
+// FakeAPI serves the pokemon, pokemon-species, type, move and
+// evolution-chain endpoints of PokeAPI from a dataset, with paginated lists.
+type FakeAPI struct {
+	Resources map[string][]FakeResource
+	// RateLimitEvery answers every nth request with 429 Too Many Requests,
+	// 0 never does.
+	RateLimitEvery int
+
+	mu       sync.Mutex
+	requests int
+}
+
+// newFakeAPI loads the dataset, each resource sorted by id.
+func newFakeAPI(dataset fs.FS, rateLimitEvery int) (*FakeAPI, error) {
+	files, err := fs.Glob(dataset, "*.json")
+	if err != nil {
+		return nil, err
+	}
+
+	resources := map[string][]FakeResource{}
+	for _, file := range files {
+		data, err := fs.ReadFile(dataset, file)
+		if err != nil {
+			return nil, err
+		}
+		var entries []json.RawMessage
+		if err := json.Unmarshal(data, &entries); err != nil {
+			return nil, fmt.Errorf("%s: %w", file, err)
+		}
+
+		resource := strings.TrimSuffix(file, ".json")
+		for _, entry := range entries {
+			var r FakeResource
+			if err := json.Unmarshal(entry, &r); err != nil {
+				return nil, fmt.Errorf("%s: %w", file, err)
+			}
+			r.Body = entry
+			resources[resource] = append(resources[resource], r)
+		}
+		sort.Slice(resources[resource], func(i, j int) bool {
+			return resources[resource][i].ID < resources[resource][j].ID
+		})
+	}
+	return &FakeAPI{Resources: resources, RateLimitEvery: rateLimitEvery}, nil
+}
+
+func (f *FakeAPI) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	f.mu.Lock()
+	f.requests++
+	rateLimited := f.RateLimitEvery > 0 && f.requests%f.RateLimitEvery == 0
+	f.mu.Unlock()
+	if rateLimited {
+		w.Header().Set("Retry-After", "1")
+		http.Error(w, "Too Many Requests", http.StatusTooManyRequests)
+		return
+	}
+
+	rest, ok := strings.CutPrefix(r.URL.Path, "/api/v2/")
+	if !ok {
+		http.NotFound(w, r)
+		return
+	}
+	parts := strings.Split(strings.Trim(rest, "/"), "/")
+	resources, ok := f.Resources[parts[0]]
+	if !ok {
+		http.NotFound(w, r)
+		return
+	}
+
+	baseURL := "http://" + r.Host + "/api/v2/"
+	switch {
+	case len(parts) == 1:
+		f.serveList(w, r, baseURL, parts[0], resources)
+	case len(parts) == 2:
+		for _, resource := range resources {
+			if resource.Name == parts[1] || strconv.Itoa(resource.ID) == parts[1] {
+				writeFakeJSON(w, baseURL, resource.Body)
+				return
+			}
+		}
+		http.NotFound(w, r)
+	case len(parts) == 3 && parts[0] == "pokemon" && parts[2] == "encounters":
+		// The dataset has no location areas, the pokemon can not be
+		// encountered anywhere.
+		writeFakeJSON(w, baseURL, []byte("[]"))
+	default:
+		http.NotFound(w, r)
+	}
+}
+
+// serveList serves a page of resources from the offset and limit query
+// parameters, linking to the next and previous pages like PokeAPI.
+func (f *FakeAPI) serveList(w http.ResponseWriter, r *http.Request, baseURL string, name string, resources []FakeResource) {
+	offset, err := strconv.Atoi(r.URL.Query().Get("offset"))
+	if err != nil || offset < 0 {
+		offset = 0
+	}
+	limit, err := strconv.Atoi(r.URL.Query().Get("limit"))
+	if err != nil || limit <= 0 {
+		limit = 20
+	}
+
+	pageURL := func(offset int) *string {
+		url := fmt.Sprintf("%s%s/?offset=%d&limit=%d", baseURL, name, offset, limit)
+		return &url
+	}
+	type result struct {
+		Name string `json:"name"`
+		URL  string `json:"url"`
+	}
+	page := struct {
+		Count    int      `json:"count"`
+		Next     *string  `json:"next"`
+		Previous *string  `json:"previous"`
+		Results  []result `json:"results"`
+	}{Count: len(resources), Results: []result{}}
+	if offset+limit < len(resources) {
+		page.Next = pageURL(offset + limit)
+	}
+	if offset > 0 {
+		page.Previous = pageURL(max(offset-limit, 0))
+	}
+	for i := offset; i < min(offset+limit, len(resources)); i++ {
+		page.Results = append(page.Results, result{
+			Name: resources[i].Name,
+			URL:  fmt.Sprintf("%s%s/%d/", baseURL, name, resources[i].ID),
+		})
+	}
+
+	body, err := json.Marshal(page)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	writeFakeJSON(w, baseURL, body)
+}
+
+// writeFakeJSON writes body with its PokeAPI urls pointing to baseURL.
+func writeFakeJSON(w http.ResponseWriter, baseURL string, body []byte) {
+	w.Header().Set("Content-Type", "application/json")
+	w.Write([]byte(strings.ReplaceAll(string(body), defaultPokeAPIURL, baseURL)))
+}
+
+// runServeFakeCommand serves the fake PokeAPI until interrupted.
+func runServeFakeCommand(args []string) error {
+	flags := flag.NewFlagSet("serve-fake", flag.ContinueOnError)
+	addr := flags.String("addr", "localhost:8080", "`address` to listen on")
+	data := flags.String("data", "", "`directory` of a dataset to serve instead of the bundled one, one JSON array per resource")
+	rateLimitEvery := flags.Int("rate-limit-every", 0, "answer every `n`th request with 429 Too Many Requests, 0 never")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+	if *rateLimitEvery < 0 {
+		return fmt.Errorf("rate-limit-every must be non-negative")
+	}
+
+	dataset, err := fakeDatasetFS(*data)
+	if err != nil {
+		return err
+	}
+	api, err := newFakeAPI(dataset, *rateLimitEvery)
+	if err != nil {
+		return err
+	}
+
+	listener, err := net.Listen("tcp", *addr)
+	if err != nil {
+		return err
+	}
+	baseURL := fmt.Sprintf("http://%s/api/v2/", listener.Addr())
+	fmt.Printf("Serving a fake PokeAPI at %s\n", baseURL)
+	fmt.Printf("Run the app against it with: POKEAPI_URL=%s pokemon-cli\n", baseURL)
+	return http.Serve(listener, api)
+}
+
+func fakeDatasetFS(dir string) (fs.FS, error) {
+	if dir != "" {
+		return os.DirFS(dir), nil
+	}
+	return fs.Sub(fakeDataset, "fakeapi")
+}
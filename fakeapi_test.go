@@ -0,0 +1,102 @@
+package main
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+// newBundledFakeAPI returns the fake PokeAPI of the bundled dataset.
+func newBundledFakeAPI(t *testing.T, rateLimitEvery int) *FakeAPI {
+	t.Helper()
+	dataset, err := fakeDatasetFS("")
+	if err != nil {
+		t.Fatal(err)
+	}
+	api, err := newFakeAPI(dataset, rateLimitEvery)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return api
+}
+
+// serveFakeAPI serves the bundled dataset for the test and points the fetch
+// functions at it.
+func serveFakeAPI(t *testing.T, rateLimitEvery int) *httptest.Server {
+	t.Helper()
+	server := httptest.NewServer(newBundledFakeAPI(t, rateLimitEvery))
+	t.Cleanup(server.Close)
+	usePokeAPI(t, nil, server.URL+"/api/v2/")
+	return server
+}
+
+func TestFakeAPIList(t *testing.T) {
+	server := serveFakeAPI(t, 0)
+
+	resp, err := http.Get(server.URL + "/api/v2/pokemon/?offset=5&limit=5")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer resp.Body.Close()
+	var pageResponse PokemonListResponse
+	if err := json.NewDecoder(resp.Body).Decode(&pageResponse); err != nil {
+		t.Fatal(err)
+	}
+	page := formatPokemonList(pageResponse)
+
+	if page.Count != 15 || len(page.Results) != 5 || page.Results[0] != "charizard" {
+		t.Errorf("page of %d from %v, want 5 of 15 from charizard", page.Count, page.Results)
+	}
+	wantNext := server.URL + "/api/v2/pokemon/?offset=10&limit=5"
+	wantPrevious := server.URL + "/api/v2/pokemon/?offset=0&limit=5"
+	if page.Next != wantNext || page.Previous != wantPrevious {
+		t.Errorf("next %v and previous %v, want %s and %s", page.Next, page.Previous, wantNext, wantPrevious)
+	}
+	if want := server.URL + "/api/v2/pokemon/6/"; pageResponse.Results[0].URL != want {
+		t.Errorf("result url %s, want %s", pageResponse.Results[0].URL, want)
+	}
+}
+
+func TestFakeAPIResources(t *testing.T) {
+	serveFakeAPI(t, 0)
+
+	pokemon, err := getPokemon("6")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if pokemon.Name != "charizard" || len(pokemon.Types) != 2 {
+		t.Errorf("getPokemon(6) = %s of types %v, want charizard fire flying", pokemon.Name, pokemon.Types)
+	}
+
+	species, err := getPokemonSpecies("mewtwo")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !species.IsLegendary {
+		t.Errorf("mewtwo species %+v, want legendary", species)
+	}
+
+	move, err := getMove("thunderbolt")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if move.Power != 90 || move.Type != "electric" {
+		t.Errorf("getMove(thunderbolt) = %+v, want a 90 power electric move", move)
+	}
+
+	if _, err := getPokemon("missingno"); errorKind(err) != NotFoundError {
+		t.Errorf("getPokemon(missingno) error %v, want not found", err)
+	}
+}
+
+func TestFakeAPIRateLimit(t *testing.T) {
+	serveFakeAPI(t, 2)
+
+	if _, err := getPokemon("pikachu"); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := getPokemon("raichu"); errorKind(err) != RateLimitedError {
+		t.Errorf("second request error %v, want rate limited", err)
+	}
+}
@@ -0,0 +1,250 @@
+package main
+
+import (
+	"container/list"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"sync"
+	"time"
+)
+
+const defaultPokeAPIURL = `https://pokeapi.co/api/v2/`
+
+// POKEAPI_URL is the base url of the API, set with the POKEAPI_URL
+// environment variable to run against another server such as serve-fake.
+var POKEAPI_URL = defaultPokeAPIURL
+
+var httpClient = http.Client{
+	Timeout: time.Second * 10,
+}
+
+// APIErrorKind classifies why a PokeAPI request failed.
+type APIErrorKind int
+
+const (
+	StatusError APIErrorKind = iota
+	NotFoundError
+	RateLimitedError
+	NetworkError
+	DecodeError
+)
+
+var apiErrorKindNames = []string{"Request failed", "Not found", "Rate limited", "Network error", "Decode error"}
+
+func (k APIErrorKind) String() string {
+	return apiErrorKindNames[k]
+}
+
+// APIError is returned by the fetch functions, Resource describes what was
+// requested and Err holds the underlying network or decode error.
+type APIError struct {
+	Kind     APIErrorKind
+	Resource string
+	Status   int
+	Err      error
+}
+
+func (e *APIError) Error() string {
+	switch e.Kind {
+	case NotFoundError:
+		return fmt.Sprintf("%s not found", e.Resource)
+	case RateLimitedError:
+		return "too many requests"
+	case NetworkError:
+		return fmt.Sprintf("could not reach PokeAPI: %v", e.Err)
+	case DecodeError:
+		return fmt.Sprintf("invalid %s data: %v", e.Resource, e.Err)
+	}
+	return fmt.Sprintf("status code: %d", e.Status)
+}
+
+func (e *APIError) Unwrap() error {
+	return e.Err
+}
+
+// errorKind returns the kind of the APIError in err, StatusError for other
+// errors.
+func errorKind(err error) APIErrorKind {
+	var apiErr *APIError
+	if errors.As(err, &apiErr) {
+		return apiErr.Kind
+	}
+	return StatusError
+}
+
+// FetchStats counts the requests in flight and the lookups in the response
+// cache, displayed in the status bar.
+type FetchStats struct {
+	Pending int
+	Hits    int
+	Misses  int
+}
+
+// maxResponseCacheBytes bounds the response cache, the least recently used
+// responses are dropped past it.
+const maxResponseCacheBytes = 64 << 20
+
+// responseCache holds the successful response bodies by url, PokeAPI data
+// does not change while the app is running.
+var (
+	responseCacheMu sync.Mutex
+	responseCache   = newLRUCache(maxResponseCacheBytes)
+	fetchStats      FetchStats
+)
+
+// lruCache holds response bodies by url up to maxBytes, dropping the least
+// recently used ones past it. It is not safe for concurrent use.
+type lruCache struct {
+	maxBytes int
+	bytes    int
+	// order holds the urls, the most recently used first.
+	order   *list.List
+	entries map[string]*list.Element
+}
+
+type lruEntry struct {
+	url  string
+	body []byte
+}
+
+func newLRUCache(maxBytes int) *lruCache {
+	return &lruCache{maxBytes: maxBytes, order: list.New(), entries: map[string]*list.Element{}}
+}
+
+func (c *lruCache) Get(url string) ([]byte, bool) {
+	e, ok := c.entries[url]
+	if !ok {
+		return nil, false
+	}
+	c.order.MoveToFront(e)
+	return e.Value.(lruEntry).body, true
+}
+
+func (c *lruCache) Add(url string, body []byte) {
+	if e, ok := c.entries[url]; ok {
+		c.bytes -= len(e.Value.(lruEntry).body)
+		c.order.Remove(e)
+	}
+	c.entries[url] = c.order.PushFront(lruEntry{url: url, body: body})
+	c.bytes += len(body)
+	for c.bytes > c.maxBytes && c.order.Len() > 1 {
+		oldest := c.order.Back()
+		entry := oldest.Value.(lruEntry)
+		c.order.Remove(oldest)
+		delete(c.entries, entry.url)
+		c.bytes -= len(entry.body)
+	}
+}
+
+func (c *lruCache) Len() int {
+	return c.order.Len()
+}
+
+func currentFetchStats() FetchStats {
+	responseCacheMu.Lock()
+	defer responseCacheMu.Unlock()
+	return fetchStats
+}
+
+// fetchBytes fetches url, or returns it from the response cache, and returns
+// the response body, resource is used to describe what was not found in the
+// error message.
+func fetchBytes(url string, resource string) ([]byte, error) {
+	return fetchResponse(url, resource, true)
+}
+
+// fetchBytesUncached is fetchBytes without adding the response to the
+// cache, for bulk lookups which would evict the responses of the routes.
+func fetchBytesUncached(url string, resource string) ([]byte, error) {
+	return fetchResponse(url, resource, false)
+}
+
+func fetchResponse(url string, resource string, cache bool) ([]byte, error) {
+	responseCacheMu.Lock()
+	body, ok := responseCache.Get(url)
+	if ok {
+		fetchStats.Hits++
+	} else {
+		fetchStats.Misses++
+		fetchStats.Pending++
+	}
+	responseCacheMu.Unlock()
+	if ok {
+		return body, nil
+	}
+
+	defer func() {
+		responseCacheMu.Lock()
+		fetchStats.Pending--
+		responseCacheMu.Unlock()
+	}()
+
+	body, err := fetchURL(url, resource)
+	if err != nil || !cache {
+		return body, err
+	}
+
+	responseCacheMu.Lock()
+	responseCache.Add(url, body)
+	responseCacheMu.Unlock()
+	return body, nil
+}
+
+func fetchURL(url string, resource string) ([]byte, error) {
+	resp, err := httpClient.Get(url)
+	if err != nil {
+		return nil, &APIError{Kind: NetworkError, Resource: resource, Err: err}
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != 200 {
+		if resp.StatusCode == 404 {
+			return nil, &APIError{Kind: NotFoundError, Resource: resource, Status: resp.StatusCode}
+		} else if resp.StatusCode == 429 {
+			return nil, &APIError{Kind: RateLimitedError, Resource: resource, Status: resp.StatusCode}
+		}
+		return nil, &APIError{Kind: StatusError, Resource: resource, Status: resp.StatusCode}
+	}
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, &APIError{Kind: NetworkError, Resource: resource, Err: err}
+	}
+	return body, nil
+}
+
+// fetchJSON fetches url and decodes the JSON body into v.
+func fetchJSON(url string, resource string, v interface{}) error {
+	body, err := fetchBytes(url, resource)
+	return decodeJSON(body, err, resource, v)
+}
+
+// fetchJSONUncached is fetchJSON without adding the response to the cache.
+func fetchJSONUncached(url string, resource string, v interface{}) error {
+	body, err := fetchBytesUncached(url, resource)
+	return decodeJSON(body, err, resource, v)
+}
+
+func decodeJSON(body []byte, err error, resource string, v interface{}) error {
+	if err != nil {
+		return err
+	}
+
+	if err := json.Unmarshal(body, v); err != nil {
+		return &APIError{Kind: DecodeError, Resource: resource, Err: err}
+	}
+	return nil
+}
+
+// clearResponseCache drops the cached responses and resets the cache
+// counters, the next lookups fetch PokeAPI again.
+func clearResponseCache() {
+	responseCacheMu.Lock()
+	responseCache = newLRUCache(maxResponseCacheBytes)
+	fetchStats.Hits = 0
+	fetchStats.Misses = 0
+	responseCacheMu.Unlock()
+}
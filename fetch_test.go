@@ -0,0 +1,169 @@
+package main
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// useFixtures serves the requests of the test from the fixtures recorded
+// from PokeAPI, or from the host of POKEAPI_URL, and records them with
+// POKEAPI_FIXTURES=record. The test is skipped when nothing was recorded
+// from the host.
+func useFixtures(t *testing.T) {
+	t.Helper()
+	mode := os.Getenv(fixturesEnv)
+	if mode == "" {
+		mode = "replay"
+	}
+	apiURL := os.Getenv("POKEAPI_URL")
+	if apiURL == "" {
+		apiURL = defaultPokeAPIURL
+	}
+	dir := filepath.Join("testdata", "fixtures")
+	if u, err := url.Parse(apiURL); mode == "replay" && err == nil {
+		if _, err := os.Stat(filepath.Join(dir, u.Host)); err != nil {
+			t.Skipf("no fixtures recorded from %s, record them with %s=record", apiURL, fixturesEnv)
+		}
+	}
+	transport, err := newFixtureTransport(mode, dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	usePokeAPI(t, transport, apiURL)
+}
+
+// usePokeAPI fetches through transport from the PokeAPI at url for the
+// test, with an empty response cache.
+func usePokeAPI(t *testing.T, transport http.RoundTripper, url string) {
+	previousTransport, previousURL := httpClient.Transport, POKEAPI_URL
+	httpClient.Transport = transport
+	POKEAPI_URL = strings.TrimSuffix(url, "/") + "/"
+	clearResponseCache()
+	t.Cleanup(func() {
+		httpClient.Transport = previousTransport
+		POKEAPI_URL = previousURL
+		clearResponseCache()
+	})
+}
+
+func TestGetPokemon(t *testing.T) {
+	useFixtures(t)
+
+	pokemon, err := getPokemon("pikachu")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if pokemon.ID != 25 || pokemon.Name != "pikachu" || pokemon.Species != "pikachu" {
+		t.Errorf("getPokemon(pikachu) = #%d %s of species %s, want #25 pikachu", pokemon.ID, pokemon.Name, pokemon.Species)
+	}
+	if len(pokemon.Types) != 1 || pokemon.Types[0] != "electric" {
+		t.Errorf("types %v, want [electric]", pokemon.Types)
+	}
+	if len(pokemon.Stats) != 6 || pokemon.Stats[0].Name != "hp" || pokemon.Stats[0].Base != 35 {
+		t.Errorf("stats %v, want the 6 base stats starting with hp 35", pokemon.Stats)
+	}
+	if pokemon.Sprite == "" || pokemon.CryLatest == "" {
+		t.Errorf("sprite %q and cry %q, want urls", pokemon.Sprite, pokemon.CryLatest)
+	}
+}
+
+func TestGetPokemonNotFound(t *testing.T) {
+	serveFakeAPI(t, 0)
+
+	_, err := getPokemon("missingno")
+	if kind := errorKind(err); err == nil || kind != NotFoundError {
+		t.Errorf("getPokemon(missingno) error %v of kind %v, want %v", err, kind, NotFoundError)
+	}
+}
+
+func TestGetPokemonList(t *testing.T) {
+	useFixtures(t)
+
+	list, err := getPokemonList(20, 20)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if list.Count <= 20 || len(list.Results) != 20 {
+		t.Fatalf("getPokemonList(20, 20) = %d of %d pokemon, want a full page of 20", len(list.Results), list.Count)
+	}
+	if list.Results[0] != "spearow" || list.Results[4] != "pikachu" {
+		t.Errorf("results %v, want the national order from spearow", list.Results)
+	}
+	if list.Next == "" || list.Previous == "" {
+		t.Errorf("next %q and previous %q, want links to the neighbouring pages", list.Next, list.Previous)
+	}
+}
+
+func TestFetchCache(t *testing.T) {
+	serveFakeAPI(t, 0)
+
+	for i := 0; i < 2; i++ {
+		if _, err := getPokemon("pikachu"); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if stats := currentFetchStats(); stats.Hits != 1 || stats.Misses != 1 || stats.Pending != 0 {
+		t.Errorf("fetch stats %+v, want 1 hit and 1 miss", stats)
+	}
+}
+
+func TestFixturesReplayRecording(t *testing.T) {
+	server := httptest.NewServer(newBundledFakeAPI(t, 0))
+	dir := t.TempDir()
+	usePokeAPI(t, recordTransport{dir: dir, next: http.DefaultTransport}, server.URL+"/api/v2/")
+	recorded, err := getPokemon("pikachu")
+	if err != nil {
+		t.Fatal(err)
+	}
+	server.Close()
+
+	// The replay reads the fixtures of the host they were recorded from.
+	usePokeAPI(t, replayTransport{dir: dir}, server.URL+"/api/v2/")
+	replayed, err := getPokemon("pikachu")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if replayed.ID != recorded.ID || replayed.Name != recorded.Name || len(replayed.Stats) != len(recorded.Stats) {
+		t.Errorf("replayed #%d %s, want the recorded #%d %s", replayed.ID, replayed.Name, recorded.ID, recorded.Name)
+	}
+	if _, err := getPokemon("mew"); err == nil {
+		t.Errorf("getPokemon(mew) succeeded without a recording")
+	}
+}
+
+func TestSearchPokemonRateLimited(t *testing.T) {
+	serveFakeAPI(t, 1)
+
+	_, err := searchPokemon("pikachu")
+	if kind := errorKind(err); kind != RateLimitedError {
+		t.Errorf("searchPokemon(pikachu) error %v of kind %v, want %v", err, kind, RateLimitedError)
+	}
+	if stats := currentFetchStats(); stats.Misses != 1 {
+		t.Errorf("%d requests, want no species lookup after a rate limited pokemon lookup", stats.Misses)
+	}
+}
+
+func TestLRUCache(t *testing.T) {
+	cache := newLRUCache(10)
+	cache.Add("a", []byte("aaaa"))
+	cache.Add("b", []byte("bbbb"))
+	cache.Get("a")
+	cache.Add("c", []byte("cccc"))
+
+	if _, ok := cache.Get("b"); ok {
+		t.Errorf("least recently used b kept past the size bound")
+	}
+	for _, url := range []string{"a", "c"} {
+		if _, ok := cache.Get(url); !ok {
+			t.Errorf("%s dropped, want only b dropped", url)
+		}
+	}
+	if cache.Len() != 2 {
+		t.Errorf("%d entries, want 2", cache.Len())
+	}
+}
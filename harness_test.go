@@ -0,0 +1,447 @@
+package main
+
+import (
+	"bytes"
+	"image"
+	"image/color"
+	"image/png"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"path"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/charmbracelet/bubbles/cursor"
+	"github.com/charmbracelet/bubbles/spinner"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/charmbracelet/x/ansi"
+)
+
+// harnessAPI serves the bundled dataset of the fake PokeAPI and a generated
+// PNG for every sprite.
+type harnessAPI struct {
+	api *FakeAPI
+}
+
+func (h harnessAPI) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if path.Ext(r.URL.Path) != ".png" {
+		h.api.ServeHTTP(w, r)
+		return
+	}
+	img := image.NewRGBA(image.Rect(0, 0, 4, 4))
+	for x := 0; x < 4; x++ {
+		for y := 0; y < 4; y++ {
+			img.Set(x, y, color.RGBA{R: 255, G: 200, A: 255})
+		}
+	}
+	var buf bytes.Buffer
+	png.Encode(&buf, img)
+	w.Write(buf.Bytes())
+}
+
+// redirectTransport sends every request to target, keeping the path and
+// query, so PokeAPI and sprite urls reach the fake server.
+type redirectTransport struct {
+	target *url.URL
+}
+
+func (t redirectTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	req = req.Clone(req.Context())
+	req.URL.Scheme = t.target.Scheme
+	req.URL.Host = t.target.Host
+	req.Host = ""
+	return http.DefaultTransport.RoundTrip(req)
+}
+
+// useTempUserDirs points the config and cache directories to temporary
+// directories for the test, so it neither reads nor writes the user's.
+func useTempUserDirs(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	t.Setenv("XDG_CONFIG_HOME", t.TempDir())
+	t.Setenv("XDG_CACHE_HOME", t.TempDir())
+}
+
+// harness drives a Model like the bubbletea program would, running the
+// commands it returns synchronously and feeding their messages back.
+type harness struct {
+	t     *testing.T
+	model Model
+}
+
+// harnessPageSize is the number of pokemon the list of the harness fetches
+// at once, less than the bundled dataset so that it scrolls over pages.
+const harnessPageSize = 8
+
+// newHarness returns a harness of a model sized width by height, fetching
+// from the fake PokeAPI of the bundled dataset with an empty response cache.
+func newHarness(t *testing.T, width int, height int) *harness {
+	useTempUserDirs(t)
+	server := httptest.NewServer(harnessAPI{api: newBundledFakeAPI(t, 0)})
+	t.Cleanup(server.Close)
+	target, _ := url.Parse(server.URL)
+	usePokeAPI(t, redirectTransport{target: target}, server.URL+"/api/v2/")
+
+	h := &harness{t: t, model: New()}
+	h.model.PokemonList.PageSize = harnessPageSize
+	// A static cursor does not blink, which would wait on a timer.
+	h.model.Pokedex.TextInput.Cursor.SetMode(cursor.CursorStatic)
+	h.model.PokemonList.NumberInput.Cursor.SetMode(cursor.CursorStatic)
+	h.model.PokemonList.PokemonList.FilterInput.Cursor.SetMode(cursor.CursorStatic)
+	h.send(tea.WindowSizeMsg{Width: width, Height: height})
+	h.run(h.model.Init())
+	return h
+}
+
+// send updates the model with msg and runs the returned command.
+func (h *harness) send(msg tea.Msg) {
+	model, cmd := h.model.Update(msg)
+	h.model = model.(Model)
+	h.run(cmd)
+}
+
+// run runs cmd and sends its messages, the spinner and cursor ticks are
+// dropped as they only animate.
+func (h *harness) run(cmd tea.Cmd) {
+	if cmd == nil {
+		return
+	}
+	switch msg := cmd().(type) {
+	case nil, spinner.TickMsg, cursor.BlinkMsg:
+	case tea.BatchMsg:
+		for _, cmd := range msg {
+			h.run(cmd)
+		}
+	default:
+		h.send(msg)
+	}
+}
+
+var harnessKeys = map[string]tea.KeyType{
+	"enter": tea.KeyEnter,
+	"esc":   tea.KeyEsc,
+	"tab":   tea.KeyTab,
+	"up":    tea.KeyUp,
+	"down":  tea.KeyDown,
+	"left":  tea.KeyLeft,
+	"right": tea.KeyRight,
+}
+
+// press sends each key, named as in tea.KeyMsg.String.
+func (h *harness) press(keys ...string) {
+	for _, k := range keys {
+		if keyType, ok := harnessKeys[k]; ok {
+			h.send(tea.KeyMsg{Type: keyType})
+		} else {
+			h.send(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune(k)})
+		}
+	}
+}
+
+// click sends a left click at x, y.
+func (h *harness) click(x int, y int) {
+	h.send(tea.MouseMsg{X: x, Y: y, Action: tea.MouseActionPress, Button: tea.MouseButtonLeft})
+}
+
+// typeText sends text one rune at a time.
+func (h *harness) typeText(text string) {
+	for _, r := range text {
+		h.send(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{r}})
+	}
+}
+
+// checkView compares the view to testdata/harness/name.golden, with the time
+// of the last error pinned so it does not depend on the clock.
+func (h *harness) checkView(name string) {
+	h.t.Helper()
+	m := h.model
+	if !m.LastErrorAt.IsZero() {
+		m.LastErrorAt = time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+	}
+	checkGolden(h.t, filepath.Join("testdata", "harness", name+".golden"), ansi.Strip(m.View()))
+}
+
+func TestHarnessPokedexSearch(t *testing.T) {
+	h := newHarness(t, 100, 30)
+	h.checkView("pokedex-start")
+
+	h.typeText("Pikachu")
+	h.press("enter")
+	if name := h.model.Pokedex.Pokemon.Name; name != "pikachu" {
+		t.Fatalf("displayed pokemon %q, want pikachu", name)
+	}
+	h.checkView("pokedex-pikachu")
+
+	h.typeText("missingno")
+	h.press("enter")
+	if errorKind(h.model.LastError) != NotFoundError {
+		t.Errorf("last error %v, want a not found error", h.model.LastError)
+	}
+	h.checkView("pokedex-not-found")
+}
+
+// loadedPokemon returns the number of pokemon in the list.
+func (h *harness) loadedPokemon() int {
+	return len(h.model.PokemonList.PokemonList.Items())
+}
+
+// listedPokemon returns the names of the pokemon of the list in order.
+func (h *harness) listedPokemon() []string {
+	names := []string{}
+	for _, item := range h.model.PokemonList.PokemonList.Items() {
+		names = append(names, item.FilterValue())
+	}
+	return names
+}
+
+// selectedPokemon returns the name of the selected pokemon of the list.
+func (h *harness) selectedPokemon() string {
+	return h.model.PokemonList.PokemonList.SelectedItem().FilterValue()
+}
+
+// scrollTo presses down until the pokemon name is selected in the list.
+func (h *harness) scrollTo(name string) {
+	h.t.Helper()
+	for i := 0; i <= h.model.PokemonList.total(); i++ {
+		if h.selectedPokemon() == name {
+			return
+		}
+		h.press("down")
+	}
+	h.t.Fatalf("scrolled to %q, want %q", h.selectedPokemon(), name)
+}
+
+func TestHarnessListScroll(t *testing.T) {
+	h := newHarness(t, 100, 30)
+	h.press("tab", "down", "enter")
+	if route := h.model.currentRoute(); route != "Pokemon List" {
+		t.Fatalf("route %q, want Pokemon List", route)
+	}
+	if loaded := h.loadedPokemon(); loaded != harnessPageSize {
+		t.Fatalf("%d pokemon loaded at start, want the first page of %d", loaded, harnessPageSize)
+	}
+	h.checkView("list-start")
+
+	h.scrollTo("ivysaur")
+	if loaded := h.loadedPokemon(); loaded != harnessPageSize {
+		t.Errorf("%d pokemon loaded a screen away from the end of the first page, want %d", loaded, harnessPageSize)
+	}
+	h.scrollTo("venusaur")
+	if loaded := h.loadedPokemon(); loaded != 15 || h.model.PokemonList.Next != "" {
+		t.Errorf("%d pokemon loaded with next page %q on nearing the end of the first page, want all 15", loaded, h.model.PokemonList.Next)
+	}
+	h.scrollTo("pikachu")
+	h.checkView("list-scrolled")
+
+	h.press("G")
+	if selected := h.selectedPokemon(); selected != "mew" {
+		t.Errorf("selected %q at the end of the list, want mew", selected)
+	}
+}
+
+func TestHarnessListFilter(t *testing.T) {
+	h := newHarness(t, 100, 30)
+	h.press("tab", "down", "enter")
+	h.press("g")
+	h.typeText("14")
+	h.press("enter")
+	if selected := h.selectedPokemon(); selected != "mewtwo" {
+		t.Fatalf("selected %q, want mewtwo", selected)
+	}
+
+	h.press("/")
+	h.typeText("snor")
+	h.press("enter")
+	matches := []string{}
+	for _, item := range h.model.PokemonList.PokemonList.VisibleItems() {
+		matches = append(matches, item.FilterValue())
+	}
+	if strings.Join(matches, ",") != "snorlax" {
+		t.Errorf("filtering snor matched %v, want snorlax of the second page", matches)
+	}
+	h.checkView("list-filter")
+}
+
+func TestHarnessListJump(t *testing.T) {
+	h := newHarness(t, 100, 30)
+	h.press("tab", "down", "enter", "g")
+	h.typeText("12")
+	h.checkView("list-jump")
+
+	h.press("enter")
+	if selected := h.selectedPokemon(); selected != "eevee" {
+		t.Fatalf("selected %q, want the 12th pokemon eevee", selected)
+	}
+	if loaded := h.loadedPokemon(); loaded < 12 {
+		t.Errorf("%d pokemon loaded after jumping to #12", loaded)
+	}
+
+	h.press("g")
+	h.typeText("3")
+	h.press("enter")
+	if selected := h.selectedPokemon(); selected != "venusaur" {
+		t.Errorf("selected %q, want the loaded #3 venusaur", selected)
+	}
+
+	h.press("g")
+	h.typeText("99")
+	h.press("enter")
+	if selected := h.selectedPokemon(); selected != "mew" {
+		t.Errorf("jumping past the end selected %q, want the last pokemon mew", selected)
+	}
+}
+
+func TestHarnessListToPokedex(t *testing.T) {
+	h := newHarness(t, 100, 30)
+	h.press("tab", "down", "enter")
+	h.scrollTo("pikachu")
+	h.press("enter")
+	if route := h.model.currentRoute(); route != "Pokedex" {
+		t.Fatalf("route %q, want Pokedex", route)
+	}
+	if name := h.model.Pokedex.Pokemon.Name; name != "pikachu" {
+		t.Fatalf("displayed pokemon %q, want pikachu", name)
+	}
+	h.checkView("list-to-pokedex")
+}
+
+func TestHarnessListSort(t *testing.T) {
+	h := newHarness(t, 100, 30)
+	h.press("tab", "down", "enter")
+
+	// The sort is stable, the pokemon of equal value stay in national
+	// order.
+	tests := []struct {
+		sort       string
+		descending bool
+		want       []string
+	}{
+		{"name", false, []string{"blastoise", "bulbasaur", "charizard", "charmander", "charmeleon", "eevee", "ivysaur", "mew",
+			"mewtwo", "pikachu", "raichu", "snorlax", "squirtle", "venusaur", "wartortle"}},
+		{"speed", true, []string{"mewtwo", "raichu", "charizard", "mew", "pikachu", "venusaur", "charmeleon", "blastoise",
+			"charmander", "ivysaur", "wartortle", "eevee", "bulbasaur", "squirtle", "snorlax"}},
+		{"speed", false, []string{"snorlax", "squirtle", "bulbasaur", "eevee", "wartortle", "ivysaur", "charmander", "blastoise",
+			"venusaur", "charmeleon", "pikachu", "charizard", "mew", "raichu", "mewtwo"}},
+		{"BST", true, []string{"mewtwo", "mew", "snorlax", "charizard", "blastoise", "venusaur", "raichu", "ivysaur",
+			"charmeleon", "wartortle", "eevee", "pikachu", "bulbasaur", "squirtle", "charmander"}},
+		{"height", true, []string{"snorlax", "venusaur", "mewtwo", "charizard", "blastoise", "charmeleon", "ivysaur", "wartortle",
+			"raichu", "bulbasaur", "charmander", "squirtle", "pikachu", "mew", "eevee"}},
+		{"weight", false, []string{"mew", "pikachu", "eevee", "bulbasaur", "charmander", "squirtle", "ivysaur", "charmeleon",
+			"wartortle", "raichu", "blastoise", "charizard", "venusaur", "mewtwo", "snorlax"}},
+	}
+	for _, tt := range tests {
+		for pokemonListSorts[h.model.PokemonList.Sort].Name != tt.sort {
+			h.press("o")
+		}
+		if h.model.PokemonList.Descending != tt.descending {
+			h.press("O")
+		}
+		if got := h.listedPokemon(); !slices.Equal(got, tt.want) {
+			t.Errorf("listed by %s descending %t:\n%v\nwant\n%v", tt.sort, tt.descending, got, tt.want)
+		}
+		if selected := h.selectedPokemon(); selected != tt.want[0] {
+			t.Errorf("selected %q first by %s descending %t, want %s", selected, tt.sort, tt.descending, tt.want[0])
+		}
+		if tt.sort == "speed" && tt.descending {
+			h.checkView("list-sort")
+		}
+	}
+}
+
+func TestHarnessListSortIndexesOnce(t *testing.T) {
+	h := newHarness(t, 100, 30)
+	h.press("tab", "down", "enter")
+	for pokemonListSorts[h.model.PokemonList.Sort].Name != "speed" {
+		h.press("o")
+	}
+	if indexed := h.model.PokemonList.indexed(); indexed != 15 {
+		t.Fatalf("%d pokemon indexed sorting by speed, want all 15", indexed)
+	}
+
+	// The sort keys are kept by the list, not the response cache, the next
+	// sorts only fetch the details of the pokemon coming on screen, a
+	// pokemon and its sprite each.
+	clearResponseCache()
+	details := len(h.model.PokemonList.Details)
+	for pokemonListSorts[h.model.PokemonList.Sort].Name != "weight" {
+		h.press("o")
+	}
+	fetched := len(h.model.PokemonList.Details) - details
+	if stats := currentFetchStats(); stats.Misses != 2*fetched {
+		t.Errorf("%d requests sorting again, want the %d of the %d pokemon coming on screen", stats.Misses, 2*fetched, fetched)
+	}
+	if got := h.listedPokemon(); got[0] != "mew" || got[len(got)-1] != "snorlax" {
+		t.Errorf("listed %v by weight, want mew to snorlax", got)
+	}
+}
+
+func TestHarnessMouse(t *testing.T) {
+	h := newHarness(t, 100, 30)
+	l := h.model.layout
+	h.click(l.Sidebar.X+1, l.Sidebar.Y+2)
+	if route := h.model.currentRoute(); route != "Pokemon List" {
+		t.Fatalf("route %q after clicking the sidebar, want Pokemon List", route)
+	}
+
+	// The list draws an empty title line, then a title, a description and a
+	// blank line per pokemon.
+	h.click(l.Display.Body.X+8, l.Display.Body.Y+1+3)
+	if name := h.model.Pokedex.Pokemon.Name; h.model.currentRoute() != "Pokedex" || name != "ivysaur" {
+		t.Fatalf("route %q displaying %q after clicking the second pokemon, want ivysaur in the Pokedex", h.model.currentRoute(), name)
+	}
+
+	header := lipgloss.Width(h.model.Pokedex.headerTitle() + "[Info] Where to find ")
+	h.click(l.Display.Header.X+1+header, l.Display.Header.Y)
+	if panel := h.model.Pokedex.Panel; panel != PokedexHeldItemsPanel {
+		t.Errorf("panel %s after clicking the Held items tab", pokedexPanelNames[panel])
+	}
+
+	h.click(l.Display.Input.X+2, l.Display.Input.Y+1)
+	if !h.model.Pokedex.TextInput.Focused() {
+		t.Errorf("search input not focused after clicking it")
+	}
+}
+
+func TestSpinnerStopsWhenIdle(t *testing.T) {
+	h := newHarness(t, 100, 30)
+	if h.model.busy() {
+		t.Fatalf("busy after loading the list")
+	}
+
+	model, cmd := h.model.Update(h.model.Spinner.Tick())
+	if cmd != nil || model.(Model).ticking {
+		t.Errorf("spinner still ticking while idle")
+	}
+}
+
+func TestHarnessHelpKeys(t *testing.T) {
+	h := newHarness(t, 100, 30)
+	h.typeText("pikachu")
+	h.press("enter", "esc")
+
+	// The help lists the keys the pokedex handles on the Info panel.
+	h.press("?")
+	view := ansi.Strip(h.model.View())
+	for _, want := range []string{"switch panel", "generation", "random pokemon"} {
+		if !strings.Contains(view, want) {
+			t.Errorf("help does not list %q", want)
+		}
+	}
+	if strings.Contains(view, "filter version") {
+		t.Errorf("help lists the encounters keys on the Info panel")
+	}
+	h.press("esc")
+
+	h.press("[", "[")
+	if panel := h.model.Pokedex.Panel; panel != PokedexStatCalcPanel {
+		t.Fatalf("panel %s after [ twice, want Stat calc", pokedexPanelNames[panel])
+	}
+	h.press("G")
+	if generation := h.model.Pokedex.Generation; generation != latestGeneration {
+		t.Errorf("generation %d after G, want %d", generation, latestGeneration)
+	}
+}
@@ -0,0 +1,126 @@
+package main
+
+import (
+	"strings"
+
+	"github.com/charmbracelet/bubbles/help"
+	"github.com/charmbracelet/bubbles/key"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// HelpOverlay is the help drawn over the main area, listing the bindings of
+// the current route, or over the whole screen listing every command.
+type HelpOverlay struct {
+	Open   bool
+	Full   bool
+	Scroll int
+}
+
+// typing reports whether the keys go to a text input of the current route,
+// where ? is typed rather than opening the help.
+func (m Model) typing() bool {
+	if m.Sidebar.IsFocused {
+		return false
+	}
+	switch route := m.currentRoute(); route {
+	case "Pokedex":
+		return m.Pokedex.TextInput.Focused()
+	case "Pokemon List":
+		return m.PokemonList.PokemonList.SettingFilter() || m.PokemonList.Jumping
+	case "Moves", "Items", "Berries":
+		return m.browser(route).List.SettingFilter()
+	case "Damage Calc":
+		return m.DamageCalc.Editing
+	case "Quiz":
+		return m.Quiz.Input.Focused()
+	}
+	return false
+}
+
+// handleHelpKey handles the keys pressed while the help is open, ? expands
+// the overlay to every command and closes it from there.
+func (m Model) handleHelpKey(msg tea.KeyMsg) (Model, tea.Cmd) {
+	switch {
+	case key.Matches(msg, globalKeys.Quit):
+		return m, tea.Quit
+	case key.Matches(msg, globalKeys.Help):
+		if m.Help.Full {
+			m.Help = HelpOverlay{}
+		} else {
+			m.Help = HelpOverlay{Open: true, Full: true}
+		}
+	case key.Matches(msg, helpKeys.Close):
+		m.Help = HelpOverlay{}
+	case key.Matches(msg, helpKeys.Up):
+		if m.Help.Scroll > 0 {
+			m.Help.Scroll--
+		}
+	case key.Matches(msg, helpKeys.Down):
+		m.Help.Scroll++
+	}
+	return m, nil
+}
+
+// keyMapView renders the enabled bindings of keyMap in columns under its
+// title.
+func keyMapView(keyMap keyMap, width int) string {
+	h := help.New()
+	h.Width = width
+	return lipgloss.JoinVertical(
+		lipgloss.Left,
+		lipgloss.NewStyle().Bold(true).Render(keyMap.Title),
+		h.FullHelpView(keyMap.FullHelp()),
+	)
+}
+
+// helpOverlayView renders the bindings of the current route and the global
+// bindings within the main area.
+func (m Model) helpOverlayView(width int, height int) string {
+	route := m.currentRoute()
+	sections := []string{
+		keyMapView(m.routeKeyMap(route, false), width-6),
+		keyMapView(globalKeyMap(), width-6),
+		"? - all commands, esc - close",
+	}
+
+	return m.styles.FocusedBorderedStyle.Width(width - 2).Height(height - 2).MaxHeight(height).Render(
+		lipgloss.JoinVertical(
+			lipgloss.Left,
+			m.styles.DisplayHeaderFocusedStyle.Render("Help - "+route),
+			m.styles.DisplayBodyFocusedStyle.Render(strings.Join(sections, "\n\n")),
+		),
+	)
+}
+
+// helpPageView renders every command of every route over width and height,
+// scrolled by the help scroll.
+func (m Model) helpPageView(width int, height int) string {
+	sections := []string{}
+	for _, route := range m.Sidebar.Routes {
+		// The browser routes share their bindings.
+		if route == "Items" || route == "Berries" {
+			continue
+		}
+		keyMap := m.routeKeyMap(route, true)
+		if route == "Moves" {
+			keyMap.Title = "Moves, Items and Berries"
+		}
+		sections = append(sections, keyMapView(keyMap, width-6))
+	}
+	sections = append(sections, keyMapView(globalKeyMap(), width-6), "? or esc - close, ↑/↓ - scroll")
+
+	// The header and the body padding take 2 of the lines within the border.
+	lines := strings.Split(strings.Join(sections, "\n\n"), "\n")
+	visible := max(height-4, 1)
+	start := min(m.Help.Scroll, max(len(lines)-visible, 0))
+	end := min(start+visible, len(lines))
+
+	return m.styles.FocusedBorderedStyle.Width(width - 2).Height(height - 2).MaxHeight(height).Render(
+		lipgloss.JoinVertical(
+			lipgloss.Left,
+			m.styles.DisplayHeaderFocusedStyle.Render("Help - all commands"),
+			m.styles.DisplayBodyFocusedStyle.Render(strings.Join(lines[start:end], "\n")),
+		),
+	)
+}
@@ -0,0 +1,99 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+)
+
+type ItemMsg struct {
+	Item   Item
+	Sprite string
+	Err    error
+}
+
+func getItem(name string) (Item, error) {
+	var itemResponse ItemResponse
+	err := fetchJSON(POKEAPI_URL+"item/"+name, "item", &itemResponse)
+	if err != nil {
+		return Item{}, err
+	}
+
+	return formatItem(itemResponse), nil
+}
+
+func formatItem(item ItemResponse) Item {
+	effect, shortEffect := "", ""
+	for _, entry := range item.EffectEntries {
+		if entry.Language.Name == "en" {
+			effect = entry.Effect
+			shortEffect = entry.ShortEffect
+		}
+	}
+
+	heldBy := []string{}
+	for _, pokemon := range item.HeldByPokemon {
+		heldBy = append(heldBy, pokemon.Pokemon.Name)
+	}
+
+	return Item{
+		Name:        item.Name,
+		Category:    item.Category.Name,
+		Cost:        item.Cost,
+		Effect:      effect,
+		ShortEffect: shortEffect,
+		Sprite:      item.Sprites.Default,
+		HeldBy:      heldBy,
+	}
+}
+
+// getItemWithSprite fetches the item named name along with its rendered
+// sprite, a missing sprite is not an error.
+func getItemWithSprite(name string) ItemMsg {
+	item, err := getItem(name)
+	if err != nil {
+		return ItemMsg{Item: Item{Name: name}, Err: err}
+	}
+
+	sprite := ""
+	if img, err := getSprite(item.Sprite); err == nil {
+		sprite = renderSprite(img, 32)
+	}
+	return ItemMsg{Item: item, Sprite: sprite}
+}
+
+func itemView(item Item, sprite string) string {
+	lines := []string{}
+	if sprite != "" {
+		lines = append(lines, sprite, "")
+	}
+	lines = append(lines,
+		fmt.Sprintf("Name: %s", item.Name),
+		fmt.Sprintf("Category: %s", item.Category),
+		fmt.Sprintf("Cost: %d", item.Cost),
+	)
+	if item.ShortEffect != "" {
+		lines = append(lines, "", item.ShortEffect)
+	}
+	if item.Effect != "" && item.Effect != item.ShortEffect {
+		lines = append(lines, "", strings.ReplaceAll(item.Effect, "\n\n", "\n"))
+	}
+	return strings.Join(lines, "\n")
+}
+
+func itemDetail(msg ItemMsg) BrowserDetail {
+	links := []BrowserLink{}
+	if strings.HasSuffix(msg.Item.Name, "-berry") {
+		berry := strings.TrimSuffix(msg.Item.Name, "-berry")
+		links = append(links, BrowserLink{Label: "berry: " + berry, Route: "Berries", Resource: "berry", Name: berry})
+	}
+	for _, pokemon := range msg.Item.HeldBy {
+		links = append(links, BrowserLink{Label: "held by " + pokemon, Route: "Pokedex", Name: pokemon})
+	}
+
+	return BrowserDetail{
+		Name:       msg.Item.Name,
+		Body:       itemView(msg.Item, msg.Sprite),
+		LinksTitle: "Related",
+		Links:      links,
+	}
+}
@@ -0,0 +1,371 @@
+package main
+
+import (
+	"github.com/charmbracelet/bubbles/key"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// keyMap lists the bindings of a route for the help overlay, disabled
+// bindings do not apply in the current state of the route. Bindings without
+// help are listed by the binding before them, such as the other direction of
+// a pair.
+type keyMap struct {
+	Title    string
+	Bindings []key.Binding
+}
+
+// helpColumnSize is the number of bindings per column of the full help.
+const helpColumnSize = 6
+
+func (k keyMap) ShortHelp() []key.Binding {
+	shown := []key.Binding{}
+	for _, binding := range k.Bindings {
+		if binding.Help().Key != "" {
+			shown = append(shown, binding)
+		}
+	}
+	return shown
+}
+
+func (k keyMap) FullHelp() [][]key.Binding {
+	enabled := []key.Binding{}
+	for _, binding := range k.ShortHelp() {
+		if binding.Enabled() {
+			enabled = append(enabled, binding)
+		}
+	}
+
+	columns := [][]key.Binding{}
+	for start := 0; start < len(enabled); start += helpColumnSize {
+		columns = append(columns, enabled[start:min(start+helpColumnSize, len(enabled))])
+	}
+	return columns
+}
+
+// newBinding returns a binding of keys shown in the help as help and desc,
+// disabled unless enabled is set. An empty help leaves it out of the help.
+func newBinding(help string, desc string, enabled bool, keys ...string) key.Binding {
+	binding := key.NewBinding(key.WithKeys(keys...), key.WithHelp(help, desc))
+	binding.SetEnabled(enabled)
+	return binding
+}
+
+// adjustKeys adjust a value by a step with left and right, by a bigger step
+// with shift.
+type adjustKeys struct {
+	Less, More       key.Binding
+	LessBig, MoreBig key.Binding
+}
+
+func newAdjustKeys(desc string, enabled bool) adjustKeys {
+	return adjustKeys{
+		Less:    newBinding("←/→", desc, enabled, "left"),
+		More:    newBinding("", "", enabled, "right"),
+		LessBig: newBinding("", "", enabled, "shift+left"),
+		MoreBig: newBinding("", "", enabled, "shift+right"),
+	}
+}
+
+// step returns the direction msg adjusts by and whether it is a bigger
+// step, a step of 0 for the other keys.
+func (k adjustKeys) step(msg tea.KeyMsg) (int, bool) {
+	switch {
+	case key.Matches(msg, k.Less):
+		return -1, false
+	case key.Matches(msg, k.More):
+		return 1, false
+	case key.Matches(msg, k.LessBig):
+		return -1, true
+	case key.Matches(msg, k.MoreBig):
+		return 1, true
+	}
+	return 0, false
+}
+
+func (k adjustKeys) bindings() []key.Binding {
+	return []key.Binding{k.Less, k.More, k.LessBig, k.MoreBig}
+}
+
+type globalKeyBindings struct {
+	FocusSidebar key.Binding
+	FocusRoute   key.Binding
+	Help         key.Binding
+	Palette      key.Binding
+	Dismiss      key.Binding
+	Quit         key.Binding
+}
+
+var globalKeys = globalKeyBindings{
+	FocusSidebar: newBinding("tab", "focus sidebar", true, "tab"),
+	FocusRoute:   newBinding("enter", "focus route", true, "enter"),
+	Help:         newBinding("?/f1", "help", true, "?", "f1"),
+	Palette:      newBinding("ctrl+p", "command palette", true, "ctrl+p"),
+	Dismiss:      newBinding("ctrl+x", "dismiss toast", true, "ctrl+x"),
+	Quit:         newBinding("ctrl+c", "quit", true, "ctrl+c"),
+}
+
+func globalKeyMap() keyMap {
+	k := globalKeys
+	return keyMap{
+		Title:    "Global",
+		Bindings: []key.Binding{k.FocusSidebar, k.FocusRoute, k.Help, k.Palette, k.Dismiss, k.Quit},
+	}
+}
+
+// helpKeys are the keys of the open help, besides the help key toggling
+// the full page.
+var helpKeys = struct {
+	Close, Up, Down key.Binding
+}{
+	Close: newBinding("esc", "close", true, "esc", "q"),
+	Up:    newBinding("↑/↓", "scroll", true, "up"),
+	Down:  newBinding("", "", true, "down"),
+}
+
+type pokedexKeys struct {
+	Search, Browse, Focus          key.Binding
+	PrevPanel, NextPanel           key.Binding
+	Up, Down                       key.Binding
+	Open, Back                     key.Binding
+	Adjust                         adjustKeys
+	NextVersion, PrevVersion       key.Binding
+	NextSprite, PrevSprite         key.Binding
+	NextGeneration, PrevGeneration key.Binding
+	Cry, LegacyCry                 key.Binding
+	Random                         key.Binding
+}
+
+// keys returns the bindings of the pokedex, enabling those that apply to the
+// focused input and the current panel, or every binding when all is set.
+func (p PokedexViewModel) keys(all bool) pokedexKeys {
+	typing := p.TextInput.Focused()
+	panels := all || !typing
+	panel := func(panels ...PokedexPanel) bool {
+		if all {
+			return true
+		}
+		for _, panel := range panels {
+			if !typing && p.Panel == panel {
+				return true
+			}
+		}
+		return false
+	}
+
+	return pokedexKeys{
+		Search:         newBinding("enter", "search", all || typing, "enter"),
+		Browse:         newBinding("esc", "browse panels", all || typing, "esc"),
+		Focus:          newBinding("/ i", "search", panels, "/", "i"),
+		PrevPanel:      newBinding("[ ]", "switch panel", panels, "["),
+		NextPanel:      newBinding("", "", panels, "]"),
+		Up:             newBinding("↑/↓", "scroll or select", panels, "up"),
+		Down:           newBinding("", "", panels, "down"),
+		Open:           newBinding("enter", "held item or variant", panel(PokedexHeldItemsPanel, PokedexVariantsPanel), "enter"),
+		Back:           newBinding("esc", "back to held items", panel(PokedexHeldItemsPanel), "esc", "backspace"),
+		Adjust:         newAdjustKeys("adjust stat, shift for more", panel(PokedexStatCalcPanel)),
+		NextVersion:    newBinding("v V", "filter version", panel(PokedexEncountersPanel), "v"),
+		PrevVersion:    newBinding("", "", panel(PokedexEncountersPanel), "V"),
+		NextSprite:     newBinding("s S", "sprite variant", panel(PokedexGalleryPanel), "s"),
+		PrevSprite:     newBinding("", "", panel(PokedexGalleryPanel), "S"),
+		NextGeneration: newBinding("g G", "generation", panels, "g"),
+		PrevGeneration: newBinding("", "", panels, "G"),
+		Cry:            newBinding("c C", "cry, legacy cry", panels, "c"),
+		LegacyCry:      newBinding("", "", panels, "C"),
+		Random:         newBinding("r", "random pokemon", panels, "r"),
+	}
+}
+
+func (p PokedexViewModel) keyMap(all bool) keyMap {
+	k := p.keys(all)
+	bindings := []key.Binding{k.Search, k.Browse, k.Focus, k.PrevPanel, k.NextPanel, k.Up, k.Down, k.Open, k.Back}
+	bindings = append(bindings, k.Adjust.bindings()...)
+	return keyMap{
+		Title: "Pokedex",
+		Bindings: append(bindings,
+			k.NextVersion, k.PrevVersion, k.NextSprite, k.PrevSprite,
+			k.NextGeneration, k.PrevGeneration, k.Cry, k.LegacyCry, k.Random,
+		),
+	}
+}
+
+type pokemonListKeys struct {
+	Up, Down                key.Binding
+	Pages                   key.Binding
+	Jump, Open, Source      key.Binding
+	NextSort, ReverseSort   key.Binding
+	Filter                  key.Binding
+	JumpTo, CancelJump      key.Binding
+	SourceUp, SourceDown    key.Binding
+	PickSource, CloseSource key.Binding
+}
+
+// keys returns the bindings of the list, the arrows and / are handled by
+// the list itself.
+func (pl PokemonListModel) keys(all bool) pokemonListKeys {
+	picking := pl.PickingSource
+	jumping := pl.Jumping
+	browsing := !picking && !jumping
+	return pokemonListKeys{
+		Up:          newBinding("↑/↓", "select pokemon", all || browsing, "up"),
+		Down:        newBinding("", "", all || browsing, "down"),
+		Pages:       newBinding("←/→", "previous, next screen", all || browsing, "left", "right"),
+		Jump:        newBinding("g", "go to number", all || browsing, "g"),
+		Open:        newBinding("enter", "open in pokedex", all || browsing, "enter"),
+		Source:      newBinding("s", "browse by", all || browsing, "s"),
+		NextSort:    newBinding("o", "next sort", all || browsing, "o"),
+		ReverseSort: newBinding("O", "reverse sort", all || browsing, "O"),
+		Filter:      newBinding("/", "filter loaded pokemon", all || browsing, "/"),
+		JumpTo:      newBinding("enter", "go to typed number", all || jumping, "enter"),
+		CancelJump:  newBinding("esc", "cancel go to number", all || jumping, "esc"),
+		SourceUp:    newBinding("↑/↓", "select source", all || picking, "up"),
+		SourceDown:  newBinding("", "", all || picking, "down"),
+		PickSource:  newBinding("enter", "browse source", all || picking, "enter"),
+		CloseSource: newBinding("esc", "close sources", all || picking, "esc", "s"),
+	}
+}
+
+func (pl PokemonListModel) keyMap(all bool) keyMap {
+	k := pl.keys(all)
+	return keyMap{
+		Title: "Pokemon List",
+		Bindings: []key.Binding{
+			k.Up, k.Down, k.Pages, k.Jump, k.Open, k.Source, k.NextSort, k.ReverseSort, k.Filter,
+			k.JumpTo, k.CancelJump, k.SourceUp, k.SourceDown, k.PickSource, k.CloseSource,
+		},
+	}
+}
+
+type browserKeys struct {
+	Up, Down           key.Binding
+	PrevPage, NextPage key.Binding
+	Show, Filter       key.Binding
+	LinkUp, LinkDown   key.Binding
+	Follow, Back       key.Binding
+}
+
+// keys returns the bindings of the browser, the arrows and / of the list are
+// handled by the list itself.
+func (b BrowserModel) keys(all bool) browserKeys {
+	detail := b.DetailFocused
+	return browserKeys{
+		Up:       newBinding("↑/↓", "select entry", all || !detail, "up"),
+		Down:     newBinding("", "", all || !detail, "down"),
+		PrevPage: newBinding("←/→", "previous, next page", all || !detail, "left"),
+		NextPage: newBinding("", "", all || !detail, "right"),
+		Show:     newBinding("enter", "show details", all || !detail, "enter"),
+		Filter:   newBinding("/", "filter page", all || !detail, "/"),
+		LinkUp:   newBinding("↑/↓", "select link", all || detail, "up"),
+		LinkDown: newBinding("", "", all || detail, "down"),
+		Follow:   newBinding("enter", "follow link", all || detail, "enter"),
+		Back:     newBinding("esc", "back to list", all || detail, "esc", "backspace"),
+	}
+}
+
+func (b BrowserModel) keyMap(all bool) keyMap {
+	k := b.keys(all)
+	return keyMap{
+		Title: b.Route,
+		Bindings: []key.Binding{
+			k.Up, k.Down, k.PrevPage, k.NextPage, k.Show, k.Filter,
+			k.LinkUp, k.LinkDown, k.Follow, k.Back,
+		},
+	}
+}
+
+type damageCalcKeys struct {
+	Up, Down        key.Binding
+	Adjust          adjustKeys
+	Edit            key.Binding
+	Confirm, Cancel key.Binding
+}
+
+func (d DamageCalcModel) keys(all bool) damageCalcKeys {
+	editing := d.Editing
+	return damageCalcKeys{
+		Up:      newBinding("↑/↓", "select field", all || !editing, "up"),
+		Down:    newBinding("", "", all || !editing, "down"),
+		Adjust:  newAdjustKeys("adjust, shift for more", all || !editing),
+		Edit:    newBinding("enter", "edit pokemon or move", all || !editing, "enter"),
+		Confirm: newBinding("enter", "confirm", all || editing, "enter"),
+		Cancel:  newBinding("esc", "cancel", all || editing, "esc"),
+	}
+}
+
+func (d DamageCalcModel) keyMap(all bool) keyMap {
+	k := d.keys(all)
+	bindings := append([]key.Binding{k.Up, k.Down}, k.Adjust.bindings()...)
+	return keyMap{
+		Title:    "Damage Calc",
+		Bindings: append(bindings, k.Edit, k.Confirm, k.Cancel),
+	}
+}
+
+type quizKeys struct {
+	Guess, GiveUp, Next key.Binding
+}
+
+// keys returns the bindings of the quiz, a pokemon that failed to load can
+// only be skipped.
+func (q QuizModel) keys(all bool) quizKeys {
+	guessing := !q.Revealed && q.Err == nil
+	return quizKeys{
+		Guess:  newBinding("enter", "guess, hint when empty", all || guessing, "enter"),
+		GiveUp: newBinding("esc", "give up", all || guessing, "esc"),
+		Next:   newBinding("enter", "next pokemon", all || !guessing, "enter"),
+	}
+}
+
+func (q QuizModel) keyMap(all bool) keyMap {
+	k := q.keys(all)
+	return keyMap{
+		Title:    "Quiz",
+		Bindings: []key.Binding{k.Guess, k.GiveUp, k.Next},
+	}
+}
+
+type teamKeys struct {
+	Up, Down       key.Binding
+	Adjust         adjustKeys
+	Select, Random key.Binding
+	Remove         key.Binding
+}
+
+func (t TeamModel) keys(all bool) teamKeys {
+	return teamKeys{
+		Up:     newBinding("↑/↓", "select", true, "up"),
+		Down:   newBinding("", "", true, "down"),
+		Adjust: newAdjustKeys("adjust constraint", true),
+		Select: newBinding("enter", "generate or open member", true, "enter"),
+		Random: newBinding("r", "random team", true, "r"),
+		Remove: newBinding("x", "remove member", all || len(t.Members) > 0, "x", "delete"),
+	}
+}
+
+func (t TeamModel) keyMap(all bool) keyMap {
+	k := t.keys(all)
+	bindings := append([]key.Binding{k.Up, k.Down}, k.Adjust.bindings()...)
+	return keyMap{
+		Title:    "Team",
+		Bindings: append(bindings, k.Select, k.Random, k.Remove),
+	}
+}
+
+// routeKeyMap returns the bindings of route, see the keyMap methods of the
+// routes for all.
+func (m Model) routeKeyMap(route string, all bool) keyMap {
+	switch route {
+	case "Pokedex":
+		return m.Pokedex.keyMap(all)
+	case "Pokemon List":
+		return m.PokemonList.keyMap(all)
+	case "Moves", "Items", "Berries":
+		return m.browser(route).keyMap(all)
+	case "Damage Calc":
+		return m.DamageCalc.keyMap(all)
+	case "Quiz":
+		return m.Quiz.keyMap(all)
+	case "Team":
+		return m.Team.keyMap(all)
+	}
+	return keyMap{Title: route}
+}
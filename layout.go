@@ -0,0 +1,266 @@
+package main
+
+import (
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/charmbracelet/x/ansi"
+)
+
+// Rect is a region of the terminal, its size includes the borders drawn in
+// it.
+type Rect struct {
+	X, Y          int
+	Width, Height int
+}
+
+func (r Rect) Contains(x int, y int) bool {
+	return x >= r.X && x < r.X+r.Width && y >= r.Y && y < r.Y+r.Height
+}
+
+// Inset returns r shrunk by n cells on every side, such as the border drawn
+// around it.
+func (r Rect) Inset(n int) Rect {
+	return Rect{X: r.X + n, Y: r.Y + n, Width: r.Width - 2*n, Height: r.Height - 2*n}
+}
+
+// Pane is a region of a split, sized by its share of Weight within its Min
+// and Max sizes, a Max of 0 leaves the pane unbounded.
+type Pane struct {
+	Min, Max int
+	Weight   int
+}
+
+// split divides total between panes by weight, clamped to their sizes. The
+// sizes always add up to total, the last unbounded pane takes what the
+// bounds leave over.
+func split(total int, panes ...Pane) []int {
+	sizes := make([]int, len(panes))
+	fixed := make([]bool, len(panes))
+	remaining := total
+
+	// Clamp the panes whose share falls outside their bounds, then share
+	// what remains between the others until every share fits.
+	for {
+		weights := 0
+		for i, pane := range panes {
+			if !fixed[i] {
+				weights += pane.Weight
+			}
+		}
+		if weights == 0 {
+			break
+		}
+
+		clamped := false
+		for i, pane := range panes {
+			if fixed[i] {
+				continue
+			}
+			share := remaining * pane.Weight / weights
+			if share < pane.Min || (pane.Max > 0 && share > pane.Max) {
+				sizes[i] = max(share, pane.Min)
+				if pane.Max > 0 {
+					sizes[i] = min(sizes[i], pane.Max)
+				}
+				fixed[i] = true
+				remaining -= sizes[i]
+				clamped = true
+			}
+		}
+		if clamped {
+			continue
+		}
+
+		last := -1
+		used := 0
+		for i, pane := range panes {
+			if !fixed[i] {
+				sizes[i] = remaining * pane.Weight / weights
+				used += sizes[i]
+				last = i
+			}
+		}
+		sizes[last] += remaining - used
+		break
+	}
+	return sizes
+}
+
+const (
+	// compactWidth is the width under which the sidebar collapses into a
+	// tab bar above the main area.
+	compactWidth = 80
+	// minLayoutWidth and minLayoutHeight is the smallest terminal the routes
+	// can be drawn in.
+	minLayoutWidth  = 40
+	minLayoutHeight = 16
+)
+
+// searchInputHeight is the height of the Pokedex search input box, borders
+// included.
+const searchInputHeight = 3
+
+// Layout holds the regions View draws the sidebar, or tab bar, the main
+// area and the status bar in, computed from the terminal size.
+type Layout struct {
+	Width, Height int
+	Compact       bool
+	Sidebar       Rect
+	TabBar        Rect
+	Main          Rect
+	StatusBar     Rect
+	// Display and Browser are the regions of the routes drawn in Main.
+	Display DisplayLayout
+	Browser BrowserLayout
+}
+
+// DisplayLayout holds the regions of the display box the Pokedex and Pokemon
+// List routes draw within the outer border of the main area, above the lines
+// of the Pokedex search input box.
+type DisplayLayout struct {
+	// Header is the line of the header, Body the lines below it, both
+	// within the display box borders.
+	Header Rect
+	Body   Rect
+	// Input is the search input box, borders included.
+	Input Rect
+}
+
+// BrowserLayout holds the regions of the list and detail boxes the Moves,
+// Items and Berries routes draw side by side within the main area.
+type BrowserLayout struct {
+	// List and Detail include their borders, ListBody is where the entries
+	// are drawn below the list header.
+	List     Rect
+	ListBody Rect
+	Detail   Rect
+}
+
+func NewLayout(width int, height int) Layout {
+	l := Layout{Width: width, Height: height, Compact: width < compactWidth}
+	l.StatusBar = Rect{Y: height - 1, Width: width, Height: 1}
+
+	if l.Compact {
+		l.TabBar = Rect{Width: width, Height: 1}
+		l.Main = Rect{Y: 1, Width: width, Height: height - 2}
+	} else {
+		sizes := split(width, Pane{Min: 18, Max: 30, Weight: 1}, Pane{Min: minLayoutWidth, Weight: 4})
+		l.Sidebar = Rect{Width: sizes[0], Height: height - 1}
+		l.Main = Rect{X: sizes[0], Width: sizes[1], Height: height - 1}
+	}
+	l.Display = newDisplayLayout(l.Main)
+	l.Browser = newBrowserLayout(l.Main)
+	return l
+}
+
+func newDisplayLayout(main Rect) DisplayLayout {
+	inner := main.Inset(1)
+	display := Rect{X: inner.X, Y: inner.Y, Width: inner.Width, Height: inner.Height - searchInputHeight}
+	content := display.Inset(1)
+	return DisplayLayout{
+		Header: Rect{X: content.X, Y: content.Y, Width: content.Width, Height: 1},
+		Body:   Rect{X: content.X, Y: content.Y + 1, Width: content.Width, Height: content.Height - 1},
+		Input:  Rect{X: inner.X, Y: display.Y + display.Height, Width: inner.Width, Height: searchInputHeight},
+	}
+}
+
+func newBrowserLayout(main Rect) BrowserLayout {
+	// The list takes 2/5 of the width within its borders.
+	listWidth := main.Width*2/5 + 2
+	list := Rect{X: main.X, Y: main.Y, Width: listWidth, Height: main.Height}
+	content := list.Inset(1)
+	return BrowserLayout{
+		List:     list,
+		ListBody: Rect{X: content.X, Y: content.Y + 1, Width: content.Width, Height: content.Height - 1},
+		Detail:   Rect{X: main.X + listWidth, Y: main.Y, Width: main.Width - listWidth, Height: main.Height},
+	}
+}
+
+// scrollLines wraps lines to width and returns the height of them that keep
+// the line at cursor visible, a height of 0 returns them all.
+func scrollLines(lines []string, cursor int, width int, height int) string {
+	wrapped := []string{}
+	cursorLine := 0
+	for i, line := range lines {
+		if i == cursor {
+			cursorLine = len(wrapped)
+		}
+		wrapped = append(wrapped, strings.Split(ansi.Wrap(line, width, ""), "\n")...)
+	}
+
+	start := 0
+	if height > 0 && cursorLine >= height {
+		start = cursorLine - height + 1
+	}
+	end := len(wrapped)
+	if height > 0 {
+		end = min(start+height, len(wrapped))
+	}
+	return strings.Join(wrapped[start:end], "\n")
+}
+
+// TooSmall reports whether the terminal is too small to draw the routes.
+func (l Layout) TooSmall() bool {
+	return l.Width < minLayoutWidth || l.Height < minLayoutHeight
+}
+
+// tabBarRange returns the routes of the tab bar that fit in width, keeping
+// the selected route visible.
+func tabBarRange(routes []string, selected int, width int) (int, int) {
+	start, end := 0, len(routes)
+	for start < selected && tabBarWidth(routes[start:end]) > width {
+		start++
+	}
+	for end > selected+1 && tabBarWidth(routes[start:end]) > width {
+		end--
+	}
+	return start, end
+}
+
+// tabBarWidth is the width of routes drawn as tabs, each padded by a space
+// on both sides, the selected one marked like in the sidebar.
+func tabBarWidth(routes []string) int {
+	width := 0
+	for _, route := range routes {
+		width += lipgloss.Width(route) + 2
+	}
+	return width
+}
+
+// TabBarView renders the routes as a single line of tabs.
+func (s SidebarModel) TabBarView(width int) string {
+	start, end := tabBarRange(s.Routes, s.SelectedRouted, width)
+	tabs := []string{}
+	for i := start; i < end; i++ {
+		style := s.Styles.UnfocusedUnselectedStyle
+		switch {
+		case i == s.SelectedRouted && s.IsFocused:
+			style = s.Styles.FocusedSelectedStyle
+		case i == s.SelectedRouted:
+			style = s.Styles.UnfocusedSelectedStyle
+		case s.IsFocused:
+			style = s.Styles.FocusedUnselectedStyle
+		}
+		marker := " "
+		if i == s.SelectedRouted {
+			marker = ">"
+		}
+		tabs = append(tabs, marker+style.Render(s.Routes[i])+" ")
+	}
+	return ansi.Truncate(strings.Join(tabs, ""), width, "")
+}
+
+// TabAt returns the route whose tab is drawn at column x of the tab bar.
+func (s SidebarModel) TabAt(x int, width int) (int, bool) {
+	start, end := tabBarRange(s.Routes, s.SelectedRouted, width)
+	left := 0
+	for i := start; i < end; i++ {
+		tabWidth := lipgloss.Width(s.Routes[i]) + 2
+		if x >= left && x < left+tabWidth {
+			return i, true
+		}
+		left += tabWidth
+	}
+	return 0, false
+}
@@ -0,0 +1,117 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/x/ansi"
+)
+
+var update = flag.Bool("update", false, "update the golden files")
+
+func TestSplit(t *testing.T) {
+	tests := []struct {
+		total int
+		panes []Pane
+		want  []int
+	}{
+		{100, []Pane{{Weight: 1}, {Weight: 4}}, []int{20, 80}},
+		{120, []Pane{{Min: 18, Max: 30, Weight: 1}, {Min: 40, Weight: 4}}, []int{24, 96}},
+		{200, []Pane{{Min: 18, Max: 30, Weight: 1}, {Min: 40, Weight: 4}}, []int{30, 170}},
+		{80, []Pane{{Min: 18, Max: 30, Weight: 1}, {Min: 40, Weight: 4}}, []int{18, 62}},
+		{101, []Pane{{Weight: 1}, {Weight: 1}}, []int{50, 51}},
+	}
+
+	for _, test := range tests {
+		got := split(test.total, test.panes...)
+		if fmt.Sprint(got) != fmt.Sprint(test.want) {
+			t.Errorf("split(%d, %v) = %v, want %v", test.total, test.panes, got, test.want)
+		}
+	}
+}
+
+func TestLayoutCompact(t *testing.T) {
+	if l := NewLayout(79, 30); !l.Compact || l.Main.Width != 79 || l.Main.Y != 1 {
+		t.Errorf("NewLayout(79, 30) = %+v, want a compact layout below the tab bar", l)
+	}
+	if l := NewLayout(80, 30); l.Compact || l.Sidebar.Width+l.Main.Width != 80 {
+		t.Errorf("NewLayout(80, 30) = %+v, want the sidebar and main area to fill the width", l)
+	}
+}
+
+// layoutModel returns a model sized width by height displaying route, with
+// fixed data instead of fetched data.
+func layoutModel(t *testing.T, width int, height int, route string) Model {
+	useTempUserDirs(t)
+	m, _ := New().Update(tea.WindowSizeMsg{Width: width, Height: height})
+	model, _ := m.(Model).focusRoute(route)
+
+	for i, name := range []string{"bulbasaur", "ivysaur", "venusaur", "charmander", "charmeleon"} {
+		model.PokemonList.Details[name] = PokemonListDetail{
+			PokemonSortKeys: PokemonSortKeys{Number: i + 1, Types: []string{"grass", "poison"}, Total: 318},
+		}
+		model.PokemonList.Entries = append(model.PokemonList.Entries, PokemonListEntry{Name: name, Number: i + 1})
+	}
+	model.PokemonList.Count = len(model.PokemonList.Entries)
+	model.PokemonList, _ = model.PokemonList.setItems()
+	model.PokemonList.Loading = false
+
+	model.Moves, _ = model.Moves.Update(BrowserListMsg{
+		Route: "Moves",
+		List:  PokemonList{Count: 3, Results: []string{"pound", "karate-chop", "double-slap"}},
+	})
+	return model
+}
+
+func TestLayoutGolden(t *testing.T) {
+	sizes := [][2]int{{60, 20}, {100, 30}, {160, 45}}
+	routes := []string{"Pokedex", "Pokemon List", "Moves", "Damage Calc", "Team"}
+
+	for _, size := range sizes {
+		for _, route := range routes {
+			width, height := size[0], size[1]
+			name := fmt.Sprintf("%s-%dx%d", strings.ToLower(strings.ReplaceAll(route, " ", "-")), width, height)
+			t.Run(name, func(t *testing.T) {
+				view := ansi.Strip(layoutModel(t, width, height, route).View())
+
+				lines := strings.Split(view, "\n")
+				if len(lines) != height {
+					t.Errorf("rendered %d lines, want %d", len(lines), height)
+				}
+				for i, line := range lines {
+					if w := ansi.StringWidth(line); w > width {
+						t.Errorf("line %d is %d wide, want at most %d", i, w, width)
+					}
+				}
+
+				checkGolden(t, filepath.Join("testdata", "layout", name+".golden"), view)
+			})
+		}
+	}
+}
+
+// checkGolden compares view to the golden file, writing it instead with the
+// -update flag.
+func checkGolden(t *testing.T, golden string, view string) {
+	t.Helper()
+	if *update {
+		if err := os.MkdirAll(filepath.Dir(golden), 0o755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(golden, []byte(view), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	want, err := os.ReadFile(golden)
+	if err != nil {
+		t.Fatalf("%v, run go test -update to create it", err)
+	}
+	if view != string(want) {
+		t.Errorf("render differs from %s, run go test -update if the change is expected\ngot:\n%s", golden, view)
+	}
+}
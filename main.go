@@ -1,10 +1,14 @@
 package main
 
 import (
+	"flag"
 	"fmt"
+	"os"
 	"strings"
+	"time"
 
-	"github.com/charmbracelet/bubbles/list"
+	"github.com/charmbracelet/bubbles/key"
+	"github.com/charmbracelet/bubbles/spinner"
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
 )
@@ -27,15 +31,36 @@ type Model struct {
 	// ROUTES
 	Pokedex     PokedexViewModel
 	PokemonList PokemonListModel
+	Moves       BrowserModel
+	Items       BrowserModel
+	Berries     BrowserModel
+	DamageCalc  DamageCalcModel
+	Quiz        QuizModel
+	Team        TeamModel
 
 	// DIMENSIONS
 	Width  int
 	Height int
+	layout Layout
 
-	Loading bool
+	// STATUS
+	Spinner spinner.Model
+	// ticking is set while a spinner tick is scheduled, see spin.
+	ticking     bool
+	LastError   error
+	LastErrorAt time.Time
+	Toasts      []Toast
+	nextToastID int
+
+	// HELP
+	Help HelpOverlay
+
+	// PALETTE
+	Palette PaletteModel
 
 	//STYLES
-	styles *Styles
+	styles     *Styles
+	lightTheme bool
 }
 
 func defaultStyles() *Styles {
@@ -87,148 +112,365 @@ func defaultSidebarStyle() *SidebarStyle {
 	}
 }
 
+func lightStyles() *Styles {
+	styles := defaultStyles()
+	styles.UnfocusedBorderedStyle = styles.UnfocusedBorderedStyle.
+		Foreground(lipgloss.Color("#888888")).
+		BorderForeground(lipgloss.Color("#bbbbbb"))
+	styles.FocusedBorderedStyle = styles.FocusedBorderedStyle.
+		Foreground(lipgloss.Color("#5500aa")).
+		BorderForeground(lipgloss.Color("#0077cc"))
+	styles.DisplayHeaderFocusedStyle = styles.DisplayHeaderFocusedStyle.Foreground(lipgloss.Color("#0055aa"))
+	styles.DisplayHeaderUnfocusedStyle = styles.DisplayHeaderUnfocusedStyle.Foreground(lipgloss.Color("#7799bb"))
+	styles.DisplayBodyFocusedStyle = styles.DisplayBodyFocusedStyle.Foreground(lipgloss.Color("#222222"))
+	styles.DisplayBodyUnfocusedStyle = styles.DisplayBodyUnfocusedStyle.Foreground(lipgloss.Color("#777777"))
+	return styles
+}
+
+func lightSidebarStyle() *SidebarStyle {
+	return &SidebarStyle{
+		FocusedSelectedStyle:     lipgloss.NewStyle().Foreground(lipgloss.Color("#0055aa")),
+		FocusedUnselectedStyle:   lipgloss.NewStyle().Foreground(lipgloss.Color("#222222")),
+		UnfocusedSelectedStyle:   lipgloss.NewStyle().Foreground(lipgloss.Color("#7799bb")),
+		UnfocusedUnselectedStyle: lipgloss.NewStyle().Foreground(lipgloss.Color("#888888")),
+	}
+}
+
+// setTheme switches between the default dark styles and the light styles.
+func (m Model) setTheme(light bool) Model {
+	m.lightTheme = light
+	if light {
+		m.styles = lightStyles()
+		m.Sidebar.Styles = lightSidebarStyle()
+	} else {
+		m.styles = defaultStyles()
+		m.Sidebar.Styles = defaultSidebarStyle()
+	}
+	return m
+}
+
 func New() Model {
 	m := NewPokedexViewModel()
 
 	s := SidebarModel{
-		Routes:         []string{"Pokedex", "Pokemon List"},
+		Routes:         []string{"Pokedex", "Pokemon List", "Moves", "Items", "Berries", "Damage Calc", "Quiz", "Team"},
 		SelectedRouted: 0,
 		Styles:         defaultSidebarStyle(),
 	}
 
 	pl := NewPokemonListModel()
+	pl.Loading = true
 
 	return Model{
 		styles:      defaultStyles(),
 		PokemonList: pl,
+		Moves:       NewBrowserModel("Moves", "move"),
+		Items:       NewBrowserModel("Items", "item"),
+		Berries:     NewBrowserModel("Berries", "berry"),
+		DamageCalc:  NewDamageCalcModel(),
+		Quiz:        NewQuizModel(),
 		Sidebar:     s,
 		Pokedex:     m,
+		Spinner:     spinner.New(spinner.WithSpinner(spinner.Dot)),
+		Palette:     NewPaletteModel(),
 	}
 }
 
 func (m Model) Init() tea.Cmd {
-	return func() tea.Msg {
-		pl, err := getPokemonList(0)
-		if err != nil {
-			return PokemonErrorMsg{Err: err}
-		}
-		return PokemonListMsg{PokemonList: pl}
-	}
+	return m.PokemonList.fetchMore(m.PokemonList.PageSize)
 }
 
 func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	model, cmd := m.update(msg)
+	return model.(Model).spin(cmd)
+}
+
+func (m Model) update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	var cmd tea.Cmd
 
+	if route, err := messageError(msg); err != nil {
+		m.LastError = err
+		m.LastErrorAt = time.Now()
+		m = m.pushToast(route, err)
+	}
+
 	switch msg := msg.(type) {
+	case spinner.TickMsg:
+		m = m.expireToasts(time.Now())
+		if !m.busy() {
+			m.ticking = false
+			return m, nil
+		}
+		m.Spinner, cmd = m.Spinner.Update(msg)
+		return m, cmd
+
 	case tea.WindowSizeMsg:
 		m.Width = msg.Width
 		m.Height = msg.Height
+		m.layout = NewLayout(m.Width, m.Height)
+		main, body := m.layout.Main, m.layout.Display.Body
+		m.Pokedex.bodyHeight = body.Height - m.styles.DisplayBodyFocusedStyle.GetVerticalFrameSize()
+		m.Pokedex.bodyWidth = body.Width - m.styles.DisplayBodyFocusedStyle.GetHorizontalFrameSize()
+		// The lists are sized like in CurrentView so their pages match what
+		// is drawn when hit-testing mouse clicks.
+		// The list help is padded past the list width by 2.
+		m.PokemonList.PokemonList.SetSize(body.Width-2, body.Height)
+		for _, route := range []string{"Moves", "Items", "Berries"} {
+			b := m.browser(route)
+			b.List.SetSize(main.Width*2/5, main.Height-4)
+		}
+
+	case tea.MouseMsg:
+		return m.handleMouse(msg)
+
+	case PaletteNamesMsg:
+		return m.setPaletteNames(msg), nil
 
 	case tea.KeyMsg:
-		switch msg.String() {
-		case "ctrl+c":
+		if m.Palette.Open {
+			return m.handlePaletteKey(msg)
+		}
+		if key.Matches(msg, globalKeys.Palette) {
+			m.Help = HelpOverlay{}
+			return m.openPalette()
+		}
+		if m.Help.Open {
+			return m.handleHelpKey(msg)
+		}
+		if key.Matches(msg, globalKeys.Help) && !m.typing() {
+			m.Help = HelpOverlay{Open: true}
+			return m, nil
+		}
+		if key.Matches(msg, globalKeys.Dismiss) {
+			return m.dismissToast(), nil
+		}
+		// The global keys to quit and focus the sidebar are not passed to the
+		// routes.
+		global := key.Matches(msg, globalKeys.Quit, globalKeys.FocusSidebar)
+		pokedexKeys := m.Pokedex.keys(false)
+		listKeys := m.PokemonList.keys(false)
+
+		if m.pokedexActive() && !m.Pokedex.TextInput.Focused() {
+			switch {
+			case global:
+			case key.Matches(msg, pokedexKeys.Random):
+				m.Pokedex.Loading = true
+				return m, randomPokemonCmd(m.Team.Constraints)
+			default:
+				m.Pokedex, cmd = m.Pokedex.handleKey(msg)
+				return m, cmd
+			}
+		}
+
+		if m.PokemonList.Jumping && !m.Sidebar.IsFocused && m.currentRoute() == "Pokemon List" && !global {
+			m.PokemonList, cmd = m.PokemonList.handleJumpKey(msg)
+			return m, cmd
+		}
+
+		if m.PokemonList.PickingSource && !m.Sidebar.IsFocused && m.currentRoute() == "Pokemon List" && !global {
+			m.PokemonList, cmd = m.PokemonList.handleSourceKey(msg)
+			return m, cmd
+		}
+
+		if !m.Sidebar.IsFocused && !global {
+			switch m.currentRoute() {
+			case "Damage Calc":
+				m.DamageCalc, cmd = m.DamageCalc.Update(msg)
+				return m, cmd
+			case "Quiz":
+				m.Quiz, cmd = m.Quiz.Update(msg)
+				return m, cmd
+			case "Team":
+				m.Team, cmd = m.Team.Update(msg)
+				return m, cmd
+			}
+		}
+
+		if b := m.browser(m.currentRoute()); b != nil && !m.Sidebar.IsFocused && !global {
+			*b, cmd = b.Update(msg)
+			return m, cmd
+		}
+
+		// The list keys apply while the list is focused and not filtered.
+		listFocused := m.PokemonList.isFocused && !m.Sidebar.IsFocused && !m.PokemonList.PokemonList.SettingFilter()
+
+		switch {
+		case key.Matches(msg, globalKeys.Quit):
 			return m, tea.Quit
 
-		case "enter":
+		case key.Matches(msg, pokedexKeys.Browse) && m.pokedexActive():
+			m.Pokedex.TextInput.Blur()
+			return m, nil
+
+		case key.Matches(msg, pokedexKeys.Search, listKeys.Open, globalKeys.FocusRoute):
 
 			if m.Pokedex.isFocused {
-				if m.Pokedex.TextInput.Focused() {
+				if key.Matches(msg, pokedexKeys.Search) {
 					searchValue := m.Pokedex.TextInput.Value()
 					if searchValue == "" {
 						break
 					}
 					m.Pokedex.TextInput.SetValue("")
+					m.Pokedex.Loading = true
 					return m, func() tea.Msg {
-						pokemon, err := getPokemon(strings.ToLower(searchValue))
+						pokemon, err := searchPokemon(strings.ToLower(searchValue))
 						if err != nil {
-							return PokemonErrorMsg{Err: err}
+							return PokemonErrorMsg{Route: "Pokedex", Err: err}
 						}
 						return PokemonMsg{Pokemon: pokemon}
 					}
 				}
 			}
 
-			if m.PokemonList.isFocused {
-				m.PokemonList.isFocused = false
-				m.Pokedex.isFocused = true
-				m.Sidebar.SelectedRouted = 0
-				m.Pokedex.TextInput.Focus()
-
-				return m, func() tea.Msg {
-					selectedItem := m.PokemonList.PokemonList.SelectedItem()
-					pokemon, err := getPokemon(strings.ToLower(selectedItem.FilterValue()))
-					if err != nil {
-						return PokemonErrorMsg{Err: err}
-					}
-					return PokemonMsg{Pokemon: pokemon}
+			if listFocused && key.Matches(msg, listKeys.Open) {
+				selectedItem := m.PokemonList.PokemonList.SelectedItem()
+				if selectedItem == nil {
+					break
 				}
+				return m.openPokemon(selectedItem.FilterValue())
 			}
 
 			if m.Sidebar.IsFocused {
-				if m.Sidebar.Routes[m.Sidebar.SelectedRouted] == "Pokedex" {
-					m.Pokedex.isFocused = true
-					m.Pokedex.TextInput.Focus()
-					m.Sidebar.IsFocused = false
-				}
-				if m.Sidebar.Routes[m.Sidebar.SelectedRouted] == "Pokemon List" {
-					m.PokemonList.isFocused = true
-					m.Sidebar.IsFocused = false
-
-				}
+				return m.focusRoute(m.currentRoute())
 			}
-		case "tab":
-			if m.Pokedex.isFocused {
-				if m.Pokedex.TextInput.Focused() {
-					m.Pokedex.TextInput.Blur()
-					m.Sidebar.IsFocused = true
-				}
-			}
-			if m.PokemonList.isFocused {
-				m.Sidebar.IsFocused = true
-			}
-
-		case "left", "right":
-			if m.PokemonList.isFocused {
-				if msg.String() == "left" {
-					if m.PokemonList.Page > 0 {
-						m.PokemonList.Page--
-					}
-				}
-				if msg.String() == "right" {
-					m.PokemonList.Page++
-				}
-				return m, func() tea.Msg {
-					pl, err := getPokemonList(m.PokemonList.Page)
-					if err != nil {
-						return PokemonErrorMsg{Err: err}
-					}
-					return PokemonListMsg{PokemonList: pl}
-				}
+		case key.Matches(msg, listKeys.Source) && listFocused:
+			m.PokemonList.PickingSource = true
+			return m, nil
+		case key.Matches(msg, listKeys.Jump) && listFocused:
+			m.PokemonList, cmd = m.PokemonList.startJump()
+			return m, cmd
+		case key.Matches(msg, listKeys.NextSort) && listFocused:
+			m.PokemonList, cmd = m.PokemonList.setSort((m.PokemonList.Sort+1)%len(pokemonListSorts), m.PokemonList.Descending)
+			return m, cmd
+		case key.Matches(msg, listKeys.ReverseSort) && listFocused:
+			m.PokemonList, cmd = m.PokemonList.setSort(m.PokemonList.Sort, !m.PokemonList.Descending)
+			return m, cmd
+
+		case key.Matches(msg, globalKeys.FocusSidebar):
+			m.Pokedex.TextInput.Blur()
+			m.Sidebar.IsFocused = true
 
-			}
 		}
 
 	case PokemonMsg:
-		m.Pokedex.Display.Body = fmt.Sprintf("Name: %s\nHeight: %d\nWeight: %d\nTypes: %s\nAbilities: %s",
-			msg.Pokemon.Name,
-			msg.Pokemon.Height,
-			msg.Pokemon.Weight,
-			strings.Join(msg.Pokemon.Types, ", "),
-			strings.Join(msg.Pokemon.Abilities, ", "),
-		)
+		m.Pokedex, cmd = m.Pokedex.SetPokemon(msg.Pokemon)
+		return m, cmd
+
+	case PokemonEncountersMsg:
+		if msg.Pokemon != m.Pokedex.Encounters.Pokemon {
+			break
+		}
+		if msg.Err != nil {
+			// The error is displayed as a toast, back on the info panel.
+			m.Pokedex.Encounters = PokedexEncounters{}
+			m.Pokedex.Panel = PokedexInfoPanel
+			break
+		}
+		m.Pokedex.Encounters.Versions = msg.Encounters
+		m.Pokedex.Encounters.Loaded = true
+
+	case ItemMsg:
+		m.Pokedex = m.Pokedex.SetItem(msg)
+
+	case CryMsg:
+		m.Pokedex = m.Pokedex.SetCry(msg)
+
+	case PokemonGalleryMsg:
+		m.Pokedex = m.Pokedex.SetGallery(msg)
+
+	case PokemonSpriteMsg:
+		m.Pokedex = m.Pokedex.SetSprite(msg)
+
+	case PokemonSpeciesMsg:
+		if msg.Species != m.Pokedex.Variants.Species {
+			break
+		}
+		if msg.Err != nil {
+			// The error is displayed as a toast, back on the info panel.
+			m.Pokedex.Variants = PokedexVariants{}
+			m.Pokedex.Panel = PokedexInfoPanel
+			break
+		}
+		m.Pokedex.Variants.Varieties = msg.Varieties
+		m.Pokedex.Variants.Loaded = true
+
+	case PokemonFormMsg:
+		m.Pokedex, cmd = m.Pokedex.SetForm(msg)
+		return m, cmd
+
+	case DamageCalcPokemonMsg, DamageCalcMoveMsg:
+		m.DamageCalc, cmd = m.DamageCalc.Update(msg)
+		return m, cmd
+
+	case RandomTeamMsg:
+		m.Team, cmd = m.Team.Update(msg)
+		return m, cmd
+
+	case FocusRouteMsg:
+		return m.focusRoute(msg.Route)
+
+	case OpenPokemonMsg:
+		return m.openPokemon(msg.Name)
+
+	case QuizPokemonMsg, QuizScoresMsg, QuizScoresSavedMsg:
+		m.Quiz, cmd = m.Quiz.Update(msg)
+		return m, cmd
+
+	case BrowserListMsg:
+		if b := m.browser(msg.Route); b != nil {
+			*b, cmd = b.Update(msg)
+		}
+		return m, cmd
+
+	case BrowserDetailMsg:
+		if b := m.browser(msg.Route); b != nil {
+			*b, cmd = b.Update(msg)
+		}
+		return m, cmd
+
+	case BrowserLinkMsg:
+		if msg.Link.Route == "Pokedex" {
+			return m.openPokemon(msg.Link.Name)
+		}
+		m, cmd = m.focusRoute(msg.Link.Route)
+		if b := m.browser(msg.Link.Route); b != nil {
+			var detailCmd tea.Cmd
+			b.DetailFocused = true
+			*b, detailCmd = b.fetchDetail(msg.Link.Resource, msg.Link.Name)
+			cmd = tea.Batch(cmd, detailCmd)
+		}
+		return m, cmd
 
 	case PokemonErrorMsg:
-		m.Pokedex.Display.Body = msg.Err.Error()
+		// The error is displayed as a toast, keeping the last result.
+		switch msg.Route {
+		case "Pokedex":
+			m.Pokedex.Loading = false
+		case "Pokemon List":
+			m.PokemonList.Loading = false
+			m.PokemonList.JumpTo = 0
+		}
 
 	case PokemonListMsg:
-		items := make([]list.Item, len(msg.PokemonList.Results))
-		for i, listItem := range msg.PokemonList.Results {
-			items[i] = PokemonListItem{
-				title: listItem,
-				desc:  "desc",
-			}
+		m.PokemonList, cmd = m.PokemonList.AppendPage(msg)
+		return m, cmd
+
+	case PokemonListDetailMsg:
+		m.PokemonList, cmd = m.PokemonList.SetDetail(msg)
+		return m, cmd
+
+	case PokemonListIndexMsg:
+		m.PokemonList, cmd = m.PokemonList.SetIndex(msg)
+		return m, cmd
+
+	case PokemonListSourceMsg:
+		if msg.Source != m.PokemonList.Source {
+			break
 		}
-		m.PokemonList.PokemonList.SetItems(items)
+		m.PokemonList.Loading = false
+		m.PokemonList.Entries = msg.Entries
+		m.PokemonList, cmd = m.PokemonList.showEntries()
+		return m, cmd
 	}
 
 	if m.Sidebar.IsFocused {
@@ -242,7 +484,16 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			m.Pokedex.TextInput, cmd = m.Pokedex.TextInput.Update(msg)
 		}
 		if m.Sidebar.Routes[m.Sidebar.SelectedRouted] == "Pokemon List" {
-			m.PokemonList.PokemonList, cmd = m.PokemonList.PokemonList.Update(msg)
+			m.PokemonList, cmd = m.PokemonList.updateList(msg)
+		}
+		if b := m.browser(m.currentRoute()); b != nil {
+			*b, cmd = b.Update(msg)
+		}
+		if m.currentRoute() == "Damage Calc" {
+			m.DamageCalc, cmd = m.DamageCalc.Update(msg)
+		}
+		if m.currentRoute() == "Quiz" {
+			m.Quiz, cmd = m.Quiz.Update(msg)
 		}
 	}
 
@@ -250,115 +501,264 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 }
 
 func (m Model) View() string {
-	if m.Loading {
-		return "Loading..."
+	l := m.layout
+	if l.TooSmall() {
+		return lipgloss.Place(m.Width, m.Height, lipgloss.Center, lipgloss.Center,
+			fmt.Sprintf("Terminal too small, resize to at least %dx%d", minLayoutWidth, minLayoutHeight))
 	}
 
-	/* SIDEBAR FOCUSED */
-	if m.Sidebar.IsFocused {
-		return lipgloss.Place(
-			m.Width,
-			m.Height,
-			lipgloss.Top,
+	/* MAIN LAYOUT */
+	main := lipgloss.NewStyle().MaxWidth(l.Main.Width).MaxHeight(l.Main.Height).Render(CurrentView(m))
+	if m.Help.Open {
+		main = m.helpOverlayView(l.Main.Width, l.Main.Height)
+	}
+	if m.Palette.Open {
+		main = m.paletteView(l.Main.Width, l.Main.Height)
+	}
+
+	var body string
+	if m.Help.Full {
+		/* HELP PAGE */
+		body = m.helpPageView(m.Width, m.Height-1)
+	} else if l.Compact {
+		/* TAB BAR LAYOUT */
+		body = lipgloss.JoinVertical(lipgloss.Left, m.Sidebar.TabBarView(l.TabBar.Width), main)
+	} else {
+		/* SIDEBAR LAYOUT */
+		sidebarStyle := m.styles.UnfocusedBorderedStyle
+		if m.Sidebar.IsFocused {
+			sidebarStyle = m.styles.FocusedBorderedStyle
+		}
+		sections := []string{m.Sidebar.View()}
+		keys := []string{}
+		for _, binding := range globalKeyMap().Bindings {
+			keys = append(keys, binding.Help().Key+" - "+binding.Help().Desc)
+		}
+		help := lipgloss.NewStyle().Foreground(lipgloss.Color("#333")).PaddingLeft(1).Width(l.Sidebar.Width - 2).
+			Render(strings.Join(keys, "\n"))
+		if spacer := l.Sidebar.Height - 2 - len(m.Sidebar.Routes) - lipgloss.Height(help); spacer >= 0 {
+			sections = append(sections, lipgloss.NewStyle().Height(spacer).Render(""), help)
+		}
+		body = lipgloss.JoinHorizontal(
 			lipgloss.Left,
-			lipgloss.JoinHorizontal(
-				lipgloss.Left,
-				/* SIDEBAR LAYOUT */
-				m.styles.FocusedBorderedStyle.Height(m.Height-3).Width(m.Width/5).Render(
-					lipgloss.JoinVertical(
-						lipgloss.Left,
-						m.Sidebar.View(),
-						lipgloss.NewStyle().Height(m.Height-8).Render(""),
-						lipgloss.NewStyle().Foreground(lipgloss.Color("#333")).PaddingLeft(1).Render("Tab - focus sidebar\nEnter - Focus route\nctrl+c - Quit"),
-					),
-				),
-				/* MAIN LAYOUT */
-				CurrentView(m),
+			sidebarStyle.Height(l.Sidebar.Height-2).Width(l.Sidebar.Width-2).Render(
+				lipgloss.JoinVertical(lipgloss.Left, sections...),
 			),
+			main,
 		)
 	}
 
-	/* SIDEBAR UNFOCUSED */
 	return lipgloss.Place(
 		m.Width,
 		m.Height,
 		lipgloss.Top,
 		lipgloss.Left,
-		lipgloss.JoinHorizontal(
+		lipgloss.JoinVertical(
 			lipgloss.Left,
-			/* SIDEBAR LAYOUT */
-			m.styles.UnfocusedBorderedStyle.Height(m.Height-3).Width(m.Width/5).Render(
-				lipgloss.JoinVertical(
-					lipgloss.Left,
-					m.Sidebar.View(),
-					lipgloss.NewStyle().Height(m.Height-8).Render(""),
-					lipgloss.NewStyle().Foreground(lipgloss.Color("#333")).PaddingLeft(1).Render("Tab - focus sidebar\nEnter - Focus route\nctrl+c - Quit"),
-				),
-			),
-			/* MAIN LAYOUT */
-			CurrentView(m),
+			m.withToasts(body),
+			/* STATUS BAR */
+			m.statusBar(),
 		),
 	)
 }
 
+func (m Model) currentRoute() string {
+	return m.Sidebar.Routes[m.Sidebar.SelectedRouted]
+}
+
+func (m Model) pokedexActive() bool {
+	return !m.Sidebar.IsFocused && m.currentRoute() == "Pokedex"
+}
+
+// browser returns the browser displayed in route, nil if the route is not a
+// browser route.
+func (m *Model) browser(route string) *BrowserModel {
+	switch route {
+	case "Moves":
+		return &m.Moves
+	case "Items":
+		return &m.Items
+	case "Berries":
+		return &m.Berries
+	}
+	return nil
+}
+
+// focusRoute selects route in the sidebar and moves the focus to it,
+// fetching its initial data if needed.
+func (m Model) focusRoute(route string) (Model, tea.Cmd) {
+	var cmd tea.Cmd
+
+	for i, r := range m.Sidebar.Routes {
+		if r == route {
+			m.Sidebar.SelectedRouted = i
+		}
+	}
+	m.Sidebar.IsFocused = false
+	m.Pokedex.isFocused = route == "Pokedex"
+	m.PokemonList.isFocused = route == "Pokemon List"
+	m.DamageCalc.isFocused = route == "Damage Calc"
+	m.Team.isFocused = route == "Team"
+
+	if m.Pokedex.isFocused {
+		cmd = m.Pokedex.TextInput.Focus()
+	} else {
+		m.Pokedex.TextInput.Blur()
+	}
+
+	m.Quiz.isFocused = route == "Quiz"
+	if m.Quiz.isFocused {
+		var scoresCmd tea.Cmd
+		m.Quiz, scoresCmd = m.Quiz.loadScores()
+		cmd = tea.Batch(m.Quiz.Input.Focus(), scoresCmd)
+		if m.Quiz.Pokemon.Name == "" && !m.Quiz.Loading {
+			var quizCmd tea.Cmd
+			m.Quiz, quizCmd = m.Quiz.Next()
+			cmd = tea.Batch(cmd, quizCmd)
+		}
+	} else {
+		m.Quiz.Input.Blur()
+	}
+
+	for _, r := range []string{"Moves", "Items", "Berries"} {
+		b := m.browser(r)
+		b.isFocused = r == route
+		if b.isFocused && len(b.List.Items()) == 0 && !b.Loading {
+			*b, cmd = b.fetchList(b.Page)
+		}
+	}
+
+	return m, cmd
+}
+
+// openPokemon moves the focus to the pokedex and searches for name.
+func (m Model) openPokemon(name string) (Model, tea.Cmd) {
+	m, cmd := m.focusRoute("Pokedex")
+	m.Pokedex.Loading = true
+	return m, tea.Batch(cmd, func() tea.Msg {
+		pokemon, err := searchPokemon(strings.ToLower(name))
+		if err != nil {
+			return PokemonErrorMsg{Route: "Pokedex", Err: err}
+		}
+		return PokemonMsg{Pokemon: pokemon}
+	})
+}
+
 func main() {
-	p := tea.NewProgram(New(), tea.WithAltScreen())
+	transport, err := newFixtureTransport(os.Getenv(fixturesEnv), fixturesDir())
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(2)
+	}
+	if transport != nil {
+		httpClient.Transport = transport
+	}
+
+	if url := os.Getenv("POKEAPI_URL"); url != "" {
+		POKEAPI_URL = strings.TrimSuffix(url, "/") + "/"
+	}
+
+	mute := flag.Bool("mute", false, "do not play pokemon cries")
+	audio := flag.String("audio", "auto", "audio `backend` playing pokemon cries: auto, none, export or a player such as ffplay, mpv or paplay")
+	exportDir := flag.String("audio-export-dir", ".", "`directory` the export audio backend copies cries to")
+	pageSize := flag.Int("page-size", 20, "`number` of pokemon the Pokemon List fetches at a time, up to 100")
+	flag.Parse()
+
+	if *pageSize < 1 || *pageSize > 100 {
+		fmt.Fprintln(os.Stderr, "page-size must be between 1 and 100")
+		os.Exit(2)
+	}
+	pokemonListPageSize = *pageSize
+
+	// The global flags come before the subcommand, which parses its own.
+	switch flag.Arg(0) {
+	case "":
+	case "random":
+		if err := runRandomCommand(flag.Args()[1:]); err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			os.Exit(1)
+		}
+		return
+	case "serve-fake":
+		if err := runServeFakeCommand(flag.Args()[1:]); err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			os.Exit(1)
+		}
+		return
+	default:
+		fmt.Fprintf(os.Stderr, "unknown command %q, want random or serve-fake\n", flag.Arg(0))
+		os.Exit(2)
+	}
+
+	backend, err := newAudioBackend(*audio, *exportDir)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(2)
+	}
+	if *mute {
+		backend = noopBackend{muted: true}
+	}
+	audioBackend = backend
+
+	p := tea.NewProgram(New(), tea.WithAltScreen(), tea.WithMouseCellMotion())
 	if _, err := p.Run(); err != nil {
 		panic(err)
 	}
 }
 
+// CurrentView renders the current route within the main area of the layout.
 func CurrentView(m Model) string {
+	m = m.withSpinner()
+	width, height := m.layout.Main.Width, m.layout.Main.Height
+
 	switch m.Sidebar.Routes[m.Sidebar.SelectedRouted] {
 	case "Pokedex":
 		/* POKEDEX FOCUSED */
 		if !m.Sidebar.IsFocused {
-			return m.styles.FocusedBorderedStyle.Height(m.Height - 3).Width(m.Width*4/5 - 3).Render(
+			return m.styles.FocusedBorderedStyle.Height(height - 2).Width(width - 2).Render(
 				lipgloss.JoinVertical(
 					lipgloss.Left,
 					/* DISPLAY */
-					m.styles.FocusedBorderedStyle.Height(m.Height-8).Width(m.Width*4/5-5).Render(
+					m.styles.FocusedBorderedStyle.Height(height-7).Width(width-4).Render(
 						lipgloss.JoinVertical(
 							lipgloss.Left,
-							m.styles.DisplayHeaderFocusedStyle.Render(m.Pokedex.Display.Header),
-							m.styles.DisplayBodyFocusedStyle.Render(m.Pokedex.Display.Body),
+							m.styles.DisplayHeaderFocusedStyle.Render(m.Pokedex.Header()),
+							m.styles.DisplayBodyFocusedStyle.Render(m.Pokedex.Body()),
 						),
 					),
 					/* INPUT */
-					m.styles.FocusedBorderedStyle.Width(m.Width*4/5-5).Render(m.Pokedex.TextInput.View()),
+					m.styles.FocusedBorderedStyle.Width(width-4).Render(m.Pokedex.TextInput.View()),
 				),
 			)
 		}
 		/* POKEDEX UNFOCUSED */
-		return m.styles.UnfocusedBorderedStyle.Height(m.Height - 3).Width(m.Width*4/5 - 3).Render(
+		return m.styles.UnfocusedBorderedStyle.Height(height - 2).Width(width - 2).Render(
 			lipgloss.JoinVertical(
 				lipgloss.Left,
 				/* DISPLAY */
-				m.styles.UnfocusedBorderedStyle.Height(m.Height-8).Width(m.Width*4/5-5).Render(
+				m.styles.UnfocusedBorderedStyle.Height(height-7).Width(width-4).Render(
 					lipgloss.JoinVertical(
 						lipgloss.Left,
-						m.styles.DisplayHeaderUnfocusedStyle.Render(m.Pokedex.Display.Header),
-						m.styles.DisplayBodyUnfocusedStyle.Render(m.Pokedex.Display.Body),
+						m.styles.DisplayHeaderUnfocusedStyle.Render(m.Pokedex.Header()),
+						m.styles.DisplayBodyUnfocusedStyle.Render(m.Pokedex.Body()),
 					),
 				),
 				/* INPUT */
-				m.styles.UnfocusedBorderedStyle.Width(m.Width*4/5-5).Render(m.Pokedex.TextInput.View()),
+				m.styles.UnfocusedBorderedStyle.Width(width-4).Render(m.Pokedex.TextInput.View()),
 			),
 		)
 	case "Pokemon List":
-		m.PokemonList.PokemonList.SetHeight(m.Height - 5)
-		m.PokemonList.PokemonList.SetWidth(m.Width - 12)
-
 		/* POKEMON LIST FOCUSED */
 		if !m.Sidebar.IsFocused {
-			return m.styles.FocusedBorderedStyle.Height(m.Height - 3).Width(m.Width*4/5 - 3).Render(
+			return m.styles.FocusedBorderedStyle.Height(height - 2).Width(width - 2).Render(
 				lipgloss.JoinVertical(
 					lipgloss.Left,
 					/* LIST */
-					m.styles.FocusedBorderedStyle.Height(m.Height-8).Width(m.Width*4/5-5).Render(
+					m.styles.FocusedBorderedStyle.Height(height-7).Width(width-4).Render(
 						lipgloss.JoinVertical(
 							lipgloss.Left,
-							m.PokemonList.PokemonList.View(),
+							m.styles.DisplayHeaderFocusedStyle.Render(m.PokemonList.Header()),
+							m.PokemonList.View(),
 						),
 					),
 				),
@@ -366,18 +766,27 @@ func CurrentView(m Model) string {
 		}
 
 		/* POKEMON LIST UNFOCUSED */
-		return m.styles.UnfocusedBorderedStyle.Height(m.Height - 3).Width(m.Width*4/5 - 3).Render(
+		return m.styles.UnfocusedBorderedStyle.Height(height - 2).Width(width - 2).Render(
 			lipgloss.JoinVertical(
 				lipgloss.Left,
 				/* LIST */
-				m.styles.UnfocusedBorderedStyle.Height(m.Height-8).Width(m.Width*4/5-5).Render(
+				m.styles.UnfocusedBorderedStyle.Height(height-7).Width(width-4).Render(
 					lipgloss.JoinVertical(
 						lipgloss.Left,
-						m.PokemonList.PokemonList.View(),
+						m.styles.DisplayHeaderUnfocusedStyle.Render(m.PokemonList.Header()),
+						m.PokemonList.View(),
 					),
 				),
 			),
 		)
+	case "Moves", "Items", "Berries":
+		return m.browser(m.currentRoute()).View(m.styles, width, height-2, !m.Sidebar.IsFocused)
+	case "Damage Calc":
+		return m.DamageCalc.View(m.styles, width, height-2, !m.Sidebar.IsFocused)
+	case "Quiz":
+		return m.Quiz.View(m.styles, width, height-2, !m.Sidebar.IsFocused)
+	case "Team":
+		return m.Team.View(m.styles, width, height-2, !m.Sidebar.IsFocused)
 	}
 	return ""
 }
@@ -0,0 +1,93 @@
+package main
+
+import (
+	"github.com/charmbracelet/bubbles/list"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+var (
+	wheelUpKey   = tea.KeyMsg{Type: tea.KeyUp}
+	wheelDownKey = tea.KeyMsg{Type: tea.KeyDown}
+)
+
+// wheelKey returns the arrow key the mouse wheel event msg scrolls like.
+func wheelKey(msg tea.MouseMsg) (tea.KeyMsg, bool) {
+	switch msg.Button {
+	case tea.MouseButtonWheelUp:
+		return wheelUpKey, true
+	case tea.MouseButtonWheelDown:
+		return wheelDownKey, true
+	}
+	return tea.KeyMsg{}, false
+}
+
+// handleMouse hit-tests mouse presses against the layout drawn by View,
+// clicking a sidebar route or tab switches to it, clicking the main area focuses it
+// and the wheel scrolls what is under the pointer.
+func (m Model) handleMouse(msg tea.MouseMsg) (Model, tea.Cmd) {
+	if msg.Action != tea.MouseActionPress {
+		return m, nil
+	}
+
+	l := m.layout
+	if l.TooSmall() || m.Help.Open || m.Palette.Open {
+		return m, nil
+	}
+	if l.Compact && l.TabBar.Contains(msg.X, msg.Y) {
+		if route, ok := m.Sidebar.TabAt(msg.X-l.TabBar.X, l.TabBar.Width); ok && msg.Button == tea.MouseButtonLeft {
+			return m.focusRoute(m.Sidebar.Routes[route])
+		}
+		return m, nil
+	}
+	if !l.Compact && l.Sidebar.Contains(msg.X, msg.Y) {
+		// The routes are drawn one per line below the top border.
+		route := msg.Y - l.Sidebar.Y - 1
+		if msg.Button == tea.MouseButtonLeft && route >= 0 && route < len(m.Sidebar.Routes) {
+			return m.focusRoute(m.Sidebar.Routes[route])
+		}
+		return m, nil
+	}
+	if !l.Main.Contains(msg.X, msg.Y) {
+		return m, nil
+	}
+
+	var cmd tea.Cmd
+	if msg.Button == tea.MouseButtonLeft && m.Sidebar.IsFocused {
+		m, cmd = m.focusRoute(m.currentRoute())
+	}
+
+	var routeCmd tea.Cmd
+	switch m.currentRoute() {
+	case "Pokedex":
+		headerPadding := m.styles.DisplayHeaderFocusedStyle.GetPaddingLeft()
+		m.Pokedex, routeCmd = m.Pokedex.handleMouse(msg, l.Display, headerPadding)
+	case "Pokemon List":
+		m.PokemonList, routeCmd = m.PokemonList.handleMouse(msg, l.Display.Body)
+	case "Moves", "Items", "Berries":
+		b := m.browser(m.currentRoute())
+		*b, routeCmd = b.handleMouse(msg, l.Browser)
+	}
+	return m, tea.Batch(cmd, routeCmd)
+}
+
+// listItemAt returns the index of the item of l drawn at line y of its view,
+// with delegate drawing the items.
+func listItemAt(l list.Model, y int, delegate list.ItemDelegate) (int, bool) {
+	itemHeight := delegate.Height() + delegate.Spacing()
+	// Without a title the title bar is an empty line, unless the filter
+	// input and its bottom padding are displayed.
+	titleHeight := 1
+	if l.FilterState() == list.Filtering {
+		titleHeight = 2
+	}
+	if y < titleHeight {
+		return 0, false
+	}
+
+	start, end := l.Paginator.GetSliceBounds(len(l.VisibleItems()))
+	index := start + (y-titleHeight)/itemHeight
+	if index >= end {
+		return 0, false
+	}
+	return index, true
+}
@@ -0,0 +1,82 @@
+package main
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+func getMove(name string) (Move, error) {
+	var moveResponse MoveResponse
+	err := fetchJSON(POKEAPI_URL+"move/"+name, "move", &moveResponse)
+	if err != nil {
+		return Move{}, err
+	}
+
+	return formatMove(moveResponse), nil
+}
+
+func formatMove(move MoveResponse) Move {
+	value := func(v *int) int {
+		if v == nil {
+			return 0
+		}
+		return *v
+	}
+
+	shortEffect := ""
+	for _, entry := range move.EffectEntries {
+		if entry.Language.Name == "en" {
+			shortEffect = strings.ReplaceAll(entry.ShortEffect, "$effect_chance", strconv.Itoa(value(move.EffectChance)))
+		}
+	}
+
+	learnedBy := []string{}
+	for _, pokemon := range move.LearnedByPokemon {
+		learnedBy = append(learnedBy, pokemon.Name)
+	}
+
+	return Move{
+		Name:         move.Name,
+		Type:         move.Type.Name,
+		DamageClass:  move.DamageClass.Name,
+		Power:        value(move.Power),
+		Accuracy:     value(move.Accuracy),
+		PP:           value(move.PP),
+		Priority:     move.Priority,
+		EffectChance: value(move.EffectChance),
+		ShortEffect:  shortEffect,
+		LearnedBy:    learnedBy,
+	}
+}
+
+func moveDetail(move Move) BrowserDetail {
+	power, accuracy := "-", "-"
+	if move.Power > 0 {
+		power = strconv.Itoa(move.Power)
+	}
+	if move.Accuracy > 0 {
+		accuracy = strconv.Itoa(move.Accuracy)
+	}
+
+	links := []BrowserLink{}
+	for _, pokemon := range move.LearnedBy {
+		links = append(links, BrowserLink{Label: pokemon, Route: "Pokedex", Name: pokemon})
+	}
+
+	return BrowserDetail{
+		Name: move.Name,
+		Body: fmt.Sprintf("Name: %s\nType: %s\nCategory: %s\nPower: %s\nAccuracy: %s\nPP: %d\nPriority: %d\n\n%s",
+			move.Name,
+			move.Type,
+			move.DamageClass,
+			power,
+			accuracy,
+			move.PP,
+			move.Priority,
+			move.ShortEffect,
+		),
+		LinksTitle: fmt.Sprintf("Learned by %d pokemon", len(links)),
+		Links:      links,
+	}
+}
@@ -0,0 +1,245 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/sahilm/fuzzy"
+)
+
+// PaletteAction is an action run from the command palette, Route names the
+// route it belongs to, empty for global actions.
+type PaletteAction struct {
+	Title string
+	Route string
+	Run   func(m Model) (Model, tea.Cmd)
+}
+
+// paletteActions holds the actions registered by the routes, each route
+// registers its own actions with registerPaletteAction from an init func.
+var paletteActions []PaletteAction
+
+func registerPaletteAction(action PaletteAction) {
+	paletteActions = append(paletteActions, action)
+}
+
+func init() {
+	registerPaletteAction(PaletteAction{
+		Title: "Toggle theme",
+		Run: func(m Model) (Model, tea.Cmd) {
+			return m.setTheme(!m.lightTheme), nil
+		},
+	})
+	registerPaletteAction(PaletteAction{
+		Title: "Clear cache",
+		Run: func(m Model) (Model, tea.Cmd) {
+			clearResponseCache()
+			return m, nil
+		},
+	})
+}
+
+// PaletteCommand is a result of the command palette.
+type PaletteCommand struct {
+	Title string
+	// Kind is route, action or pokemon.
+	Kind string
+	Run  func(m Model) (Model, tea.Cmd)
+}
+
+// paletteResults is the number of results listed for a query.
+const paletteResults = 50
+
+type PaletteModel struct {
+	Open    bool
+	Input   textinput.Model
+	Cursor  int
+	Matches []PaletteCommand
+	// Names holds the name of every pokemon, fetched the first time the
+	// palette is opened.
+	Names        []string
+	NamesLoading bool
+	Err          error
+}
+
+type PaletteNamesMsg struct {
+	Names []string
+	Err   error
+}
+
+func NewPaletteModel() PaletteModel {
+	ti := textinput.New()
+	ti.Placeholder = "Jump to a route, pokemon or action"
+	ti.CharLimit = 64
+	return PaletteModel{Input: ti}
+}
+
+func getPokemonNames() tea.Msg {
+	var pokemonResponse PokemonListResponse
+	if err := fetchJSON(POKEAPI_URL+"pokemon/?limit=100000", "Pokemon list", &pokemonResponse); err != nil {
+		return PaletteNamesMsg{Err: err}
+	}
+	return PaletteNamesMsg{Names: formatPokemonList(pokemonResponse).Results}
+}
+
+// paletteCommands lists the routes of the sidebar, the registered actions and,
+// when withPokemon is set, every pokemon.
+func (m Model) paletteCommands(withPokemon bool) []PaletteCommand {
+	commands := []PaletteCommand{}
+	for _, route := range m.Sidebar.Routes {
+		route := route
+		commands = append(commands, PaletteCommand{
+			Title: "Go to " + route,
+			Kind:  "route",
+			Run: func(m Model) (Model, tea.Cmd) {
+				return m.focusRoute(route)
+			},
+		})
+	}
+	for _, action := range paletteActions {
+		title := action.Title
+		if action.Route != "" {
+			title = action.Route + ": " + title
+		}
+		commands = append(commands, PaletteCommand{Title: title, Kind: "action", Run: action.Run})
+	}
+	if withPokemon {
+		for _, name := range m.Palette.Names {
+			name := name
+			commands = append(commands, PaletteCommand{
+				Title: name,
+				Kind:  "pokemon",
+				Run: func(m Model) (Model, tea.Cmd) {
+					return m.openPokemon(name)
+				},
+			})
+		}
+	}
+	return commands
+}
+
+// filterPalette matches the commands against the query, without a query the
+// routes and actions are listed.
+func (m Model) filterPalette() Model {
+	query := strings.TrimSpace(m.Palette.Input.Value())
+	if query == "" {
+		m.Palette.Matches = m.paletteCommands(false)
+	} else {
+		commands := m.paletteCommands(true)
+		titles := make([]string, len(commands))
+		for i, command := range commands {
+			titles[i] = command.Title
+		}
+		m.Palette.Matches = []PaletteCommand{}
+		for _, match := range fuzzy.Find(query, titles) {
+			if len(m.Palette.Matches) == paletteResults {
+				break
+			}
+			m.Palette.Matches = append(m.Palette.Matches, commands[match.Index])
+		}
+	}
+	m.Palette.Cursor = min(m.Palette.Cursor, max(len(m.Palette.Matches)-1, 0))
+	return m
+}
+
+func (m Model) openPalette() (Model, tea.Cmd) {
+	m.Palette.Open = true
+	m.Palette.Cursor = 0
+	m.Palette.Input.SetValue("")
+	cmd := m.Palette.Input.Focus()
+	m = m.filterPalette()
+	if m.Palette.Names == nil && !m.Palette.NamesLoading {
+		m.Palette.NamesLoading = true
+		m.Palette.Err = nil
+		cmd = tea.Batch(cmd, getPokemonNames)
+	}
+	return m, cmd
+}
+
+func (m Model) closePalette() Model {
+	m.Palette.Open = false
+	m.Palette.Input.Blur()
+	return m
+}
+
+// setPaletteNames lists the pokemon fetched with msg in the results.
+func (m Model) setPaletteNames(msg PaletteNamesMsg) Model {
+	m.Palette.NamesLoading = false
+	m.Palette.Names = msg.Names
+	m.Palette.Err = msg.Err
+	return m.filterPalette()
+}
+
+// handlePaletteKey handles the keys pressed while the palette is open, the
+// other keys edit the query.
+func (m Model) handlePaletteKey(msg tea.KeyMsg) (Model, tea.Cmd) {
+	switch msg.String() {
+	case "ctrl+c":
+		return m, tea.Quit
+	case "esc", "ctrl+p":
+		return m.closePalette(), nil
+	case "up":
+		if m.Palette.Cursor > 0 {
+			m.Palette.Cursor--
+		}
+		return m, nil
+	case "down":
+		if m.Palette.Cursor < len(m.Palette.Matches)-1 {
+			m.Palette.Cursor++
+		}
+		return m, nil
+	case "enter":
+		if len(m.Palette.Matches) == 0 {
+			return m, nil
+		}
+		command := m.Palette.Matches[m.Palette.Cursor]
+		return command.Run(m.closePalette())
+	}
+
+	var cmd tea.Cmd
+	m.Palette.Input, cmd = m.Palette.Input.Update(msg)
+	m.Palette.Cursor = 0
+	return m.filterPalette(), cmd
+}
+
+// paletteView renders the query and the results that fit in height within
+// the main area.
+func (m Model) paletteView(width int, height int) string {
+	lines := []string{}
+	switch {
+	case m.Palette.Err != nil:
+		lines = append(lines, "Could not load the pokemon names: "+m.Palette.Err.Error())
+	case m.Palette.NamesLoading:
+		lines = append(lines, loadingView(m.Spinner.View())+" pokemon names")
+	}
+
+	visible := max(height-8-len(lines), 1)
+	start := 0
+	if m.Palette.Cursor >= visible {
+		start = m.Palette.Cursor - visible + 1
+	}
+	end := min(start+visible, len(m.Palette.Matches))
+	for i := start; i < end; i++ {
+		command := m.Palette.Matches[i]
+		cursor := "  "
+		if i == m.Palette.Cursor {
+			cursor = "> "
+		}
+		lines = append(lines, fmt.Sprintf("%s%-8s %s", cursor, command.Kind, command.Title))
+	}
+	if len(m.Palette.Matches) == 0 {
+		lines = append(lines, "No matches")
+	}
+
+	return m.styles.FocusedBorderedStyle.Width(width - 2).Height(height - 2).MaxHeight(height).Render(
+		lipgloss.JoinVertical(
+			lipgloss.Left,
+			m.styles.DisplayHeaderFocusedStyle.Render("Command palette (enter - run, esc - close)"),
+			m.styles.FocusedBorderedStyle.Width(width-4).Render(m.Palette.Input.View()),
+			m.styles.DisplayBodyFocusedStyle.Render(strings.Join(lines, "\n")),
+		),
+	)
+}
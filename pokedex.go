@@ -1,19 +1,41 @@
 package main
 
 import (
-	"encoding/json"
+	"errors"
 	"fmt"
-	"net/http"
-	"time"
+	"sort"
+	"strings"
 
+	"github.com/charmbracelet/bubbles/key"
 	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
 )
 
 type PokedexViewModel struct {
-	Display   PokedexDisplay
-	TextInput textinput.Model
-	isFocused bool
+	Display    PokedexDisplay
+	TextInput  textinput.Model
+	isFocused  bool
+	Pokemon    Pokemon
+	Panel      PokedexPanel
+	Scroll     int
+	Encounters PokedexEncounters
+	HeldItems  PokedexHeldItems
+	Variants   PokedexVariants
+	StatCalc   StatCalc
+	Gallery    PokedexGallery
+	Sprite     string
+	// Generation is the generation context the typing is displayed in, 0
+	// displays the current typing.
+	Generation int
+	// Status is a short message displayed next to the header, such as the
+	// state of the cry being played.
+	Status string
+	// Loading is set while the searched pokemon is fetched.
+	Loading    bool
+	spinner    string
+	bodyHeight int
+	bodyWidth  int
 }
 
 type PokedexDisplay struct {
@@ -21,6 +43,36 @@ type PokedexDisplay struct {
 	Body   string
 }
 
+type PokedexPanel int
+
+const (
+	PokedexInfoPanel PokedexPanel = iota
+	PokedexEncountersPanel
+	PokedexHeldItemsPanel
+	PokedexVariantsPanel
+	PokedexStatCalcPanel
+	PokedexGalleryPanel
+)
+
+var pokedexPanelNames = []string{"Info", "Where to find", "Held items", "Variants", "Stat calc", "Sprites"}
+
+type PokedexEncounters struct {
+	Pokemon  string
+	Versions []PokemonEncounterVersion
+	Loaded   bool
+	// Version is the index of the filtered version in Versions plus one, 0
+	// shows all versions.
+	Version int
+}
+
+type PokedexHeldItems struct {
+	Cursor  int
+	Loading bool
+	// Item holds the details of the drilled into item, nil shows the list of
+	// held items.
+	Item *ItemMsg
+}
+
 func NewPokedexViewModel() PokedexViewModel {
 	ti := textinput.New()
 	ti.Placeholder = "Search for a pokemon"
@@ -35,34 +87,474 @@ func NewPokedexViewModel() PokedexViewModel {
 		},
 		TextInput: ti,
 		isFocused: true,
+		StatCalc:  NewStatCalc(),
 	}
 }
 
-func getPokemon(name string) (Pokemon, error) {
-	const POKEMON_API = `https://pokeapi.co/api/v2/pokemon/`
+func init() {
+	registerPaletteAction(PaletteAction{
+		Title: "Add to team",
+		Route: "Pokedex",
+		Run: func(m Model) (Model, tea.Cmd) {
+			if m.Pokedex.Pokemon.Name == "" {
+				return m.pushToast("Pokedex", errors.New("no pokemon to add to the team")), nil
+			}
+			m.Team = m.Team.Add(m.Pokedex.Pokemon)
+			m.Pokedex.Status = "added to the team"
+			return m, nil
+		},
+	})
+	registerPaletteAction(PaletteAction{
+		Title: "Play cry",
+		Route: "Pokedex",
+		Run: func(m Model) (Model, tea.Cmd) {
+			if m.Pokedex.Pokemon.Name == "" {
+				return m, nil
+			}
+			m.Pokedex.Status = "♪ " + m.Pokedex.Pokemon.Name
+			return m, playCry(m.Pokedex.Pokemon, false)
+		},
+	})
+	registerPaletteAction(PaletteAction{
+		Title: "Random pokemon",
+		Route: "Pokedex",
+		Run: func(m Model) (Model, tea.Cmd) {
+			m, cmd := m.focusRoute("Pokedex")
+			m.Pokedex.Loading = true
+			return m, tea.Batch(cmd, randomPokemonCmd(m.Team.Constraints))
+		},
+	})
+}
 
-	c := http.Client{
-		Timeout: time.Second * 10,
+// SetPokemon displays pokemon and resets the state of the panels that depend
+// on the previously displayed pokemon.
+func (p PokedexViewModel) SetPokemon(pokemon Pokemon) (PokedexViewModel, tea.Cmd) {
+	p.Pokemon = pokemon
+	p.Loading = false
+	p.Scroll = 0
+	p.Encounters = PokedexEncounters{}
+	p.HeldItems = PokedexHeldItems{}
+	p.Gallery = PokedexGallery{Variant: p.Gallery.Variant}
+	p.Sprite = ""
+	p.Status = ""
+	if p.Variants.Species != pokemon.Species {
+		p.Variants = PokedexVariants{}
+	}
+	if p.Variants.Cursor >= len(p.Variants.Entries(pokemon)) {
+		p.Variants.Cursor = 0
 	}
 
-	resp, err := c.Get(POKEMON_API + name)
-	if err != nil {
-		return Pokemon{}, err
+	p.Display.Body = p.infoBody()
+
+	p, cmd := p.loadPanel()
+	if pokemon.Sprite != "" {
+		cmd = tea.Batch(cmd, getPokemonSprite(pokemon.Sprite))
+	}
+	return p, cmd
+}
+
+// Types returns the types the displayed pokemon had in the generation
+// context, the first past types recorded for a later generation apply.
+func (p PokedexViewModel) Types() []string {
+	if p.Generation == 0 {
+		return p.Pokemon.Types
+	}
+	for _, pastTypes := range p.Pokemon.PastTypes {
+		if pastTypes.Generation >= p.Generation {
+			return pastTypes.Types
+		}
+	}
+	return p.Pokemon.Types
+}
+
+func (p PokedexViewModel) infoBody() string {
+	stats := []string{}
+	total := 0
+	for _, stat := range p.Pokemon.Stats {
+		stats = append(stats, fmt.Sprintf("  %-16s%3d", stat.Name, stat.Base))
+		total += stat.Base
+	}
+	stats = append(stats, fmt.Sprintf("  %-16s%3d", "total", total))
+
+	types := strings.Join(p.Types(), ", ")
+	if p.Generation != 0 {
+		types += " (" + generationName(p.Generation) + ")"
+		if current := strings.Join(p.Pokemon.Types, ", "); current != strings.Join(p.Types(), ", ") {
+			types += " - historical typing, currently " + current
+		}
+	}
+
+	return fmt.Sprintf("Name: %s\nHeight: %d\nWeight: %d\nTypes: %s\nAbilities: %s\n\nBase stats:\n%s\n\nDamage taken:\n%s",
+		p.Pokemon.Name,
+		p.Pokemon.Height,
+		p.Pokemon.Weight,
+		types,
+		strings.Join(p.Pokemon.Abilities, ", "),
+		strings.Join(stats, "\n"),
+		typeMatchupsView(p.Types(), p.Generation),
+	)
+}
+
+// SetCry displays the outcome of playing the cry of the displayed pokemon.
+func (p PokedexViewModel) SetCry(msg CryMsg) PokedexViewModel {
+	if msg.Pokemon != p.Pokemon.Name {
+		return p
+	}
+	p.Status = cryStatus(msg)
+	return p
+}
+
+// SetSprite displays the rendered sprite of the displayed pokemon.
+func (p PokedexViewModel) SetSprite(msg PokemonSpriteMsg) PokedexViewModel {
+	if msg.URL == p.Pokemon.Sprite {
+		p.Sprite = msg.Sprite
+	}
+	return p
+}
+
+// loadPanel fetches the data of the current panel if it was not fetched for
+// the displayed pokemon yet.
+func (p PokedexViewModel) loadPanel() (PokedexViewModel, tea.Cmd) {
+	if p.Pokemon.Name == "" {
+		return p, nil
+	}
+
+	switch p.Panel {
+	case PokedexEncountersPanel:
+		if p.Encounters.Pokemon == p.Pokemon.Name {
+			return p, nil
+		}
+		p.Encounters = PokedexEncounters{Pokemon: p.Pokemon.Name}
+		name, url := p.Pokemon.Name, p.Pokemon.LocationAreaEncounters
+		return p, func() tea.Msg {
+			encounters, err := getPokemonEncounters(url)
+			return PokemonEncountersMsg{Pokemon: name, Encounters: encounters, Err: err}
+		}
+	case PokedexVariantsPanel:
+		if p.Variants.Species == p.Pokemon.Species {
+			return p, nil
+		}
+		p.Variants = PokedexVariants{Species: p.Pokemon.Species}
+		species := p.Pokemon.Species
+		return p, func() tea.Msg {
+			varieties, err := getPokemonVarieties(species)
+			return PokemonSpeciesMsg{Species: species, Varieties: varieties, Err: err}
+		}
+	case PokedexGalleryPanel:
+		if p.Gallery.Pokemon == p.Pokemon.Name {
+			return p, nil
+		}
+		p.Gallery = PokedexGallery{Pokemon: p.Pokemon.Name, Variant: p.Gallery.Variant}
+		return p, getPokemonGallery(p.Pokemon.Name, p.Pokemon.SpriteVersions, p.Gallery.Variant)
 	}
-	defer resp.Body.Close()
+	return p, nil
+}
 
-	if resp.StatusCode != 200 {
-		if resp.StatusCode == 404 {
-			return Pokemon{}, fmt.Errorf("pokemon not found")
-		} else if resp.StatusCode == 429 {
-			return Pokemon{}, fmt.Errorf("too many requests")
+// handleKey handles the keys pressed while the pokedex is focused and the
+// search input is not.
+func (p PokedexViewModel) handleKey(msg tea.KeyMsg) (PokedexViewModel, tea.Cmd) {
+	k := p.keys(false)
+	switch {
+	case key.Matches(msg, k.Focus):
+		return p, p.TextInput.Focus()
+	case key.Matches(msg, k.NextPanel):
+		p.Panel = (p.Panel + 1) % PokedexPanel(len(pokedexPanelNames))
+		p.Scroll = 0
+		return p.loadPanel()
+	case key.Matches(msg, k.PrevPanel):
+		p.Panel = (p.Panel + PokedexPanel(len(pokedexPanelNames)) - 1) % PokedexPanel(len(pokedexPanelNames))
+		p.Scroll = 0
+		return p.loadPanel()
+	case key.Matches(msg, k.Up):
+		if p.Panel == PokedexHeldItemsPanel && p.HeldItems.Item == nil {
+			if p.HeldItems.Cursor > 0 {
+				p.HeldItems.Cursor--
+			}
+			break
+		}
+		if p.Panel == PokedexVariantsPanel {
+			if p.Variants.Cursor > 0 {
+				p.Variants.Cursor--
+			}
+			break
+		}
+		if p.Panel == PokedexStatCalcPanel {
+			if p.StatCalc.Cursor > 0 {
+				p.StatCalc.Cursor--
+			}
+			break
+		}
+		if p.Scroll > 0 {
+			p.Scroll--
+		}
+	case key.Matches(msg, k.Down):
+		if p.Panel == PokedexHeldItemsPanel && p.HeldItems.Item == nil {
+			if p.HeldItems.Cursor < len(p.Pokemon.HeldItems)-1 {
+				p.HeldItems.Cursor++
+			}
+			break
+		}
+		if p.Panel == PokedexVariantsPanel {
+			if p.Variants.Cursor < len(p.Variants.Entries(p.Pokemon))-1 {
+				p.Variants.Cursor++
+			}
+			break
+		}
+		if p.Panel == PokedexStatCalcPanel {
+			if p.StatCalc.Cursor < p.StatCalc.cursorCount()-1 {
+				p.StatCalc.Cursor++
+			}
+			break
+		}
+		if p.Scroll < p.maxScroll() {
+			p.Scroll++
+		}
+	case key.Matches(msg, k.Open):
+		if p.Panel == PokedexVariantsPanel && p.Variants.Loaded {
+			p.Loading = true
+			return p, p.selectVariant()
+		}
+		if p.Panel == PokedexHeldItemsPanel && p.HeldItems.Item == nil && len(p.Pokemon.HeldItems) > 0 {
+			p.HeldItems.Loading = true
+			name := p.Pokemon.HeldItems[p.HeldItems.Cursor].Name
+			return p, func() tea.Msg {
+				return getItemWithSprite(name)
+			}
+		}
+	case key.Matches(msg, k.Back):
+		p.HeldItems.Item = nil
+		p.HeldItems.Loading = false
+		p.Scroll = 0
+	case key.Matches(msg, k.Adjust.bindings()...):
+		step, big := k.Adjust.step(msg)
+		p.StatCalc = p.StatCalc.adjust(step, big)
+	case key.Matches(msg, k.Cry, k.LegacyCry):
+		if p.Pokemon.Name != "" {
+			p.Status = "♪ " + p.Pokemon.Name
+			return p, playCry(p.Pokemon, key.Matches(msg, k.LegacyCry))
+		}
+	case key.Matches(msg, k.NextGeneration, k.PrevGeneration):
+		if key.Matches(msg, k.NextGeneration) {
+			p.Generation = (p.Generation + 1) % (latestGeneration + 1)
+		} else {
+			p.Generation = (p.Generation + latestGeneration) % (latestGeneration + 1)
+		}
+		if p.Pokemon.Name != "" {
+			p.Display.Body = p.infoBody()
+		}
+	case key.Matches(msg, k.NextSprite, k.PrevSprite):
+		count := len(spriteVariants)
+		if key.Matches(msg, k.NextSprite) {
+			p.Gallery.Variant = (p.Gallery.Variant + 1) % count
+		} else {
+			p.Gallery.Variant = (p.Gallery.Variant + count - 1) % count
+		}
+		p.Gallery.Pokemon = ""
+		p.Scroll = 0
+		return p.loadPanel()
+	case key.Matches(msg, k.NextVersion, k.PrevVersion):
+		if len(p.Encounters.Versions) > 0 {
+			count := len(p.Encounters.Versions) + 1
+			if key.Matches(msg, k.NextVersion) {
+				p.Encounters.Version = (p.Encounters.Version + 1) % count
+			} else {
+				p.Encounters.Version = (p.Encounters.Version + count - 1) % count
+			}
+			p.Scroll = 0
 		}
-		return Pokemon{}, fmt.Errorf("status code: %d", resp.StatusCode)
 	}
+	return p, nil
+}
 
+func (p PokedexViewModel) Header() string {
+	tabs := []string{}
+	for i, name := range pokedexPanelNames {
+		if PokedexPanel(i) == p.Panel {
+			tabs = append(tabs, "["+name+"]")
+		} else {
+			tabs = append(tabs, name)
+		}
+	}
+	header := p.headerTitle() + strings.Join(tabs, " ")
+	if p.compactHeader() {
+		header = fmt.Sprintf("%s[%s] %d/%d", p.headerTitle(), pokedexPanelNames[p.Panel], p.Panel+1, len(pokedexPanelNames))
+	}
+	if p.Loading {
+		header += " " + p.spinner
+	}
+	if p.Status != "" {
+		header += " | " + p.Status
+	}
+	return header
+}
+
+// headerTitle returns the part of the header before the panel tabs.
+func (p PokedexViewModel) headerTitle() string {
+	title := p.Display.Header
+	if p.Generation != 0 {
+		title += " (" + generationName(p.Generation) + ")"
+	}
+	return title + " - "
+}
+
+// compactHeader reports whether the tabs of every panel do not fit in the
+// header, which then only names the current panel.
+func (p PokedexViewModel) compactHeader() bool {
+	width := lipgloss.Width(p.headerTitle()) + len(pokedexPanelNames) + 1
+	for _, name := range pokedexPanelNames {
+		width += lipgloss.Width(name)
+	}
+	return p.bodyWidth > 0 && width > p.bodyWidth
+}
+
+// panelAt returns the panel whose tab is drawn at column x of the header.
+func (p PokedexViewModel) panelAt(x int) (PokedexPanel, bool) {
+	if p.compactHeader() {
+		return 0, false
+	}
+	start := lipgloss.Width(p.headerTitle())
+	for i, name := range pokedexPanelNames {
+		// Every tab is followed by a space, the selected one is bracketed.
+		width := lipgloss.Width(name)
+		if PokedexPanel(i) == p.Panel {
+			width += 2
+		}
+		if x >= start && x < start+width {
+			return PokedexPanel(i), true
+		}
+		start += width + 1
+	}
+	return 0, false
+}
+
+// handleMouse handles mouse presses on the pokedex drawn in l with its
+// header padded by headerPadding, clicking a tab switches panels, clicking
+// the panel or the search input focuses it and the wheel scrolls the panel.
+func (p PokedexViewModel) handleMouse(msg tea.MouseMsg, l DisplayLayout, headerPadding int) (PokedexViewModel, tea.Cmd) {
+	if key, ok := wheelKey(msg); ok {
+		return p.handleKey(key)
+	}
+	if msg.Button != tea.MouseButtonLeft {
+		return p, nil
+	}
+
+	if l.Input.Contains(msg.X, msg.Y) {
+		return p, p.TextInput.Focus()
+	}
+	p.TextInput.Blur()
+	if l.Header.Contains(msg.X, msg.Y) {
+		if panel, ok := p.panelAt(msg.X - l.Header.X - headerPadding); ok {
+			p.Panel = panel
+			p.Scroll = 0
+			return p.loadPanel()
+		}
+	}
+	return p, nil
+}
+
+func (p PokedexViewModel) panelBody() string {
+	if p.Pokemon.Name == "" {
+		return p.Display.Body
+	}
+
+	switch p.Panel {
+	case PokedexInfoPanel:
+		if p.Sprite == "" {
+			return p.Display.Body
+		}
+		return lipgloss.JoinHorizontal(lipgloss.Top, p.Sprite, "  ", p.Display.Body)
+	case PokedexEncountersPanel:
+		if !p.Encounters.Loaded {
+			return loadingView(p.spinner)
+		}
+		version := ""
+		filter := "all versions"
+		if p.Encounters.Version > 0 {
+			version = p.Encounters.Versions[p.Encounters.Version-1].Version
+			filter = version
+		}
+		return fmt.Sprintf("Where to find %s (v - %s)\n\n%s", p.Pokemon.Name, filter, encountersView(p.Encounters.Versions, version))
+	case PokedexHeldItemsPanel:
+		if p.HeldItems.Item != nil {
+			return itemView(p.HeldItems.Item.Item, p.HeldItems.Item.Sprite)
+		}
+		if p.HeldItems.Loading {
+			return loadingView(p.spinner)
+		}
+		return p.heldItemsView()
+	case PokedexVariantsPanel:
+		if !p.Variants.Loaded {
+			return loadingView(p.spinner)
+		}
+		return p.variantsView()
+	case PokedexStatCalcPanel:
+		return p.StatCalc.View(p.Pokemon)
+	case PokedexGalleryPanel:
+		if !p.Gallery.Loaded {
+			return loadingView(p.spinner)
+		}
+		return p.galleryView(p.bodyWidth)
+	}
+	return ""
+}
+
+func (p PokedexViewModel) heldItemsView() string {
+	if len(p.Pokemon.HeldItems) == 0 {
+		return p.Pokemon.Name + " holds no items in the wild"
+	}
+
+	lines := []string{fmt.Sprintf("Items %s holds in the wild (enter - item details)", p.Pokemon.Name), ""}
+	for i, heldItem := range p.Pokemon.HeldItems {
+		cursor := "  "
+		if i == p.HeldItems.Cursor {
+			cursor = "> "
+		}
+		rarities := []string{}
+		for _, rarity := range heldItem.Rarities {
+			rarities = append(rarities, fmt.Sprintf("%s %d%%", rarity.Version, rarity.Rarity))
+		}
+		lines = append(lines, cursor+heldItem.Name, "    "+strings.Join(rarities, ", "))
+	}
+	return strings.Join(lines, "\n")
+}
+
+// SetItem displays the details of the held item fetched with msg, a failed
+// fetch keeps the held items listed.
+func (p PokedexViewModel) SetItem(msg ItemMsg) PokedexViewModel {
+	if !p.HeldItems.Loading || len(p.Pokemon.HeldItems) == 0 || p.Pokemon.HeldItems[p.HeldItems.Cursor].Name != msg.Item.Name {
+		return p
+	}
+	p.HeldItems.Loading = false
+	if msg.Err != nil {
+		return p
+	}
+	p.HeldItems.Item = &msg
+	p.Scroll = 0
+	return p
+}
+
+func (p PokedexViewModel) maxScroll() int {
+	lines := strings.Count(p.panelBody(), "\n") + 1
+	if lines <= p.bodyHeight {
+		return 0
+	}
+	return lines - p.bodyHeight
+}
+
+// Body renders the visible part of the current panel.
+func (p PokedexViewModel) Body() string {
+	lines := strings.Split(p.panelBody(), "\n")
+	start := min(p.Scroll, p.maxScroll())
+	end := len(lines)
+	if p.bodyHeight > 0 {
+		end = min(start+p.bodyHeight, len(lines))
+	}
+	return strings.Join(lines[start:end], "\n")
+}
+
+func getPokemon(name string) (Pokemon, error) {
 	var pokemonResponse PokemonResponse
-	err = json.NewDecoder(resp.Body).Decode(&pokemonResponse)
-	if err != nil {
+	if err := fetchJSON(POKEAPI_URL+"pokemon/"+name, "pokemon", &pokemonResponse); err != nil {
 		return Pokemon{}, err
 	}
 
@@ -81,12 +573,69 @@ func formatPokemon(pokemon PokemonResponse) Pokemon {
 		PokemonAbilities = append(PokemonAbilities, pokemonAbility.Ability.Name)
 	}
 
+	PokemonForms := []string{}
+	for _, form := range pokemon.Forms {
+		PokemonForms = append(PokemonForms, form.Name)
+	}
+
+	PokemonStats := []PokemonStat{}
+	for _, stat := range pokemon.Stats {
+		PokemonStats = append(PokemonStats, PokemonStat{
+			Name: stat.Stat.Name,
+			Base: stat.BaseStat,
+		})
+	}
+
+	PokemonPastTypesList := []PokemonPastTypes{}
+	for _, pastTypes := range pokemon.PastTypes {
+		types := []string{}
+		for _, pastType := range pastTypes.Types {
+			types = append(types, pastType.Type.Name)
+		}
+		PokemonPastTypesList = append(PokemonPastTypesList, PokemonPastTypes{
+			Generation: generationNumber(pastTypes.Generation.Name),
+			Types:      types,
+		})
+	}
+	sort.Slice(PokemonPastTypesList, func(i, j int) bool {
+		return PokemonPastTypesList[i].Generation < PokemonPastTypesList[j].Generation
+	})
+
+	PokemonHeldItems := []PokemonHeldItem{}
+	for _, heldItem := range pokemon.HeldItems {
+		rarities := []PokemonHeldItemRarity{}
+		for _, versionDetail := range heldItem.VersionDetails {
+			rarities = append(rarities, PokemonHeldItemRarity{
+				Version: versionDetail.Version.Name,
+				Rarity:  versionDetail.Rarity,
+			})
+		}
+		sort.SliceStable(rarities, func(i, j int) bool {
+			return versionIndex(rarities[i].Version) < versionIndex(rarities[j].Version)
+		})
+		PokemonHeldItems = append(PokemonHeldItems, PokemonHeldItem{
+			Name:     heldItem.Item.Name,
+			Rarities: rarities,
+		})
+	}
+
 	return Pokemon{
-		Name:      pokemon.Name,
-		Height:    pokemon.Height,
-		Weight:    pokemon.Weight,
-		Types:     PokemonTypes,
-		Abilities: PokemonAbilities,
+		ID:                     pokemon.ID,
+		Name:                   pokemon.Name,
+		Height:                 pokemon.Height,
+		Weight:                 pokemon.Weight,
+		Types:                  PokemonTypes,
+		Abilities:              PokemonAbilities,
+		LocationAreaEncounters: pokemon.LocationAreaEncounters,
+		HeldItems:              PokemonHeldItems,
+		Species:                pokemon.Species.Name,
+		Forms:                  PokemonForms,
+		Stats:                  PokemonStats,
+		Sprite:                 pokemon.Sprites.FrontDefault,
+		PastTypes:              PokemonPastTypesList,
+		CryLatest:              pokemon.Cries.Latest,
+		CryLegacy:              pokemon.Cries.Legacy,
+		SpriteVersions:         formatSpriteVersions(pokemon),
 	}
 }
 
@@ -94,8 +643,11 @@ type PokemonMsg struct {
 	Pokemon Pokemon
 }
 
+// PokemonErrorMsg reports a failed pokemon or pokemon list fetch, Route is
+// the route the fetch was made for.
 type PokemonErrorMsg struct {
-	Err error
+	Route string
+	Err   error
 }
 
 func (p PokemonErrorMsg) Error() string {
@@ -0,0 +1,136 @@
+package main
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// versionOrder lists the game versions in release order, used to sort
+// per-version data which PokeAPI returns in no particular order.
+var versionOrder = []string{
+	"red", "blue", "yellow",
+	"gold", "silver", "crystal",
+	"ruby", "sapphire", "emerald", "firered", "leafgreen",
+	"diamond", "pearl", "platinum", "heartgold", "soulsilver",
+	"black", "white", "black-2", "white-2",
+	"x", "y", "omega-ruby", "alpha-sapphire",
+	"sun", "moon", "ultra-sun", "ultra-moon", "lets-go-pikachu", "lets-go-eevee",
+	"sword", "shield", "the-isle-of-armor", "the-crown-tundra",
+	"brilliant-diamond", "shining-pearl", "legends-arceus",
+	"scarlet", "violet", "the-teal-mask", "the-indigo-disk",
+}
+
+func versionIndex(version string) int {
+	for i, v := range versionOrder {
+		if v == version {
+			return i
+		}
+	}
+	return len(versionOrder)
+}
+
+type PokemonEncountersMsg struct {
+	Pokemon    string
+	Encounters []PokemonEncounterVersion
+	Err        error
+}
+
+func getPokemonEncounters(url string) ([]PokemonEncounterVersion, error) {
+	var encounterResponse PokemonEncounterResponse
+	err := fetchJSON(url, "encounters", &encounterResponse)
+	if err != nil {
+		return nil, err
+	}
+
+	return formatPokemonEncounters(encounterResponse), nil
+}
+
+// formatPokemonEncounters regroups the location area based response by game
+// version, merging identical encounter slots by summing their chance.
+func formatPokemonEncounters(encounterResponse PokemonEncounterResponse) []PokemonEncounterVersion {
+	versions := []PokemonEncounterVersion{}
+	versionIndexes := map[string]int{}
+
+	for _, locationArea := range encounterResponse {
+		for _, versionDetail := range locationArea.VersionDetails {
+			i, ok := versionIndexes[versionDetail.Version.Name]
+			if !ok {
+				i = len(versions)
+				versionIndexes[versionDetail.Version.Name] = i
+				versions = append(versions, PokemonEncounterVersion{Version: versionDetail.Version.Name})
+			}
+
+			area := PokemonEncounterArea{
+				Name:      locationArea.LocationArea.Name,
+				MaxChance: versionDetail.MaxChance,
+			}
+			for _, encounterDetail := range versionDetail.EncounterDetails {
+				conditions := []string{}
+				for _, condition := range encounterDetail.ConditionValues {
+					conditions = append(conditions, condition.Name)
+				}
+				detail := PokemonEncounterDetail{
+					Method:     encounterDetail.Method.Name,
+					MinLevel:   encounterDetail.MinLevel,
+					MaxLevel:   encounterDetail.MaxLevel,
+					Chance:     encounterDetail.Chance,
+					Conditions: conditions,
+				}
+
+				merged := false
+				for j, existing := range area.Details {
+					if existing.Method == detail.Method &&
+						existing.MinLevel == detail.MinLevel &&
+						existing.MaxLevel == detail.MaxLevel &&
+						strings.Join(existing.Conditions, ",") == strings.Join(detail.Conditions, ",") {
+						area.Details[j].Chance += detail.Chance
+						merged = true
+						break
+					}
+				}
+				if !merged {
+					area.Details = append(area.Details, detail)
+				}
+			}
+			versions[i].Areas = append(versions[i].Areas, area)
+		}
+	}
+
+	sort.SliceStable(versions, func(i, j int) bool {
+		return versionIndex(versions[i].Version) < versionIndex(versions[j].Version)
+	})
+	return versions
+}
+
+// encountersView renders the encounters grouped by version, when version is
+// not empty only that version is rendered.
+func encountersView(versions []PokemonEncounterVersion, version string) string {
+	if len(versions) == 0 {
+		return "Not found in the wild"
+	}
+
+	lines := []string{}
+	for _, v := range versions {
+		if version != "" && v.Version != version {
+			continue
+		}
+		lines = append(lines, strings.ToUpper(v.Version))
+		for _, area := range v.Areas {
+			lines = append(lines, fmt.Sprintf("  %s (max %d%%)", area.Name, area.MaxChance))
+			for _, detail := range area.Details {
+				levels := fmt.Sprintf("Lv. %d", detail.MinLevel)
+				if detail.MaxLevel != detail.MinLevel {
+					levels = fmt.Sprintf("Lv. %d-%d", detail.MinLevel, detail.MaxLevel)
+				}
+				line := fmt.Sprintf("    %s, %s, %d%%", detail.Method, levels, detail.Chance)
+				if len(detail.Conditions) > 0 {
+					line += " [" + strings.Join(detail.Conditions, ", ") + "]"
+				}
+				lines = append(lines, line)
+			}
+		}
+		lines = append(lines, "")
+	}
+	return strings.TrimRight(strings.Join(lines, "\n"), "\n")
+}
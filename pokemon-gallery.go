@@ -0,0 +1,218 @@
+package main
+
+import (
+	"strings"
+	"sync"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// PokemonSpriteVersion holds the sprites of a pokemon in a game, an empty
+// URL means the game has no sprite for that variant.
+type PokemonSpriteVersion struct {
+	Generation  int
+	Game        string
+	Default     string
+	Shiny       string
+	Female      string
+	ShinyFemale string
+}
+
+var spriteVariants = []string{"default", "shiny", "female", "shiny female"}
+
+// galleryCellWidth is the width of a sprite in the gallery grid, including
+// the space between sprites.
+const galleryCellWidth = 26
+
+func (v PokemonSpriteVersion) URL(variant int) string {
+	switch variant {
+	case 1:
+		return v.Shiny
+	case 2:
+		return v.Female
+	case 3:
+		return v.ShinyFemale
+	}
+	return v.Default
+}
+
+type PokedexGallery struct {
+	Pokemon string
+	Variant int
+	// Sprites holds the rendered sprites by game.
+	Sprites map[string]string
+	Loaded  bool
+}
+
+type PokemonGalleryMsg struct {
+	Pokemon string
+	Variant int
+	Sprites map[string]string
+}
+
+// optionalSprite returns the URL of a sprite the API may leave null.
+func optionalSprite(sprite interface{}) string {
+	url, _ := sprite.(string)
+	return url
+}
+
+// formatSpriteVersions lists the sprites of every game from Red/Blue to
+// Ultra Sun/Ultra Moon.
+func formatSpriteVersions(pokemon PokemonResponse) []PokemonSpriteVersion {
+	versions := pokemon.Sprites.Versions
+	genI, genII, genIII := versions.GenerationI, versions.GenerationIi, versions.GenerationIii
+	genIV, genV, genVI, genVII := versions.GenerationIv, versions.GenerationV, versions.GenerationVi, versions.GenerationVii
+
+	all := []PokemonSpriteVersion{
+		{Generation: 1, Game: "Red/Blue", Default: genI.RedBlue.FrontDefault},
+		{Generation: 1, Game: "Yellow", Default: genI.Yellow.FrontDefault},
+		{Generation: 2, Game: "Gold", Default: genII.Gold.FrontDefault, Shiny: genII.Gold.FrontShiny},
+		{Generation: 2, Game: "Silver", Default: genII.Silver.FrontDefault, Shiny: genII.Silver.FrontShiny},
+		{Generation: 2, Game: "Crystal", Default: genII.Crystal.FrontDefault, Shiny: genII.Crystal.FrontShiny},
+		{Generation: 3, Game: "Ruby/Sapphire", Default: genIII.RubySapphire.FrontDefault, Shiny: genIII.RubySapphire.FrontShiny},
+		{Generation: 3, Game: "Emerald", Default: genIII.Emerald.FrontDefault, Shiny: genIII.Emerald.FrontShiny},
+		{Generation: 3, Game: "FireRed/LeafGreen", Default: genIII.FireredLeafgreen.FrontDefault, Shiny: genIII.FireredLeafgreen.FrontShiny},
+		{
+			Generation:  4,
+			Game:        "Diamond/Pearl",
+			Default:     genIV.DiamondPearl.FrontDefault,
+			Shiny:       genIV.DiamondPearl.FrontShiny,
+			Female:      optionalSprite(genIV.DiamondPearl.FrontFemale),
+			ShinyFemale: optionalSprite(genIV.DiamondPearl.FrontShinyFemale),
+		},
+		{
+			Generation:  4,
+			Game:        "Platinum",
+			Default:     genIV.Platinum.FrontDefault,
+			Shiny:       genIV.Platinum.FrontShiny,
+			Female:      optionalSprite(genIV.Platinum.FrontFemale),
+			ShinyFemale: optionalSprite(genIV.Platinum.FrontShinyFemale),
+		},
+		{
+			Generation:  4,
+			Game:        "HeartGold/SoulSilver",
+			Default:     genIV.HeartgoldSoulsilver.FrontDefault,
+			Shiny:       genIV.HeartgoldSoulsilver.FrontShiny,
+			Female:      optionalSprite(genIV.HeartgoldSoulsilver.FrontFemale),
+			ShinyFemale: optionalSprite(genIV.HeartgoldSoulsilver.FrontShinyFemale),
+		},
+		{
+			Generation:  5,
+			Game:        "Black/White",
+			Default:     genV.BlackWhite.FrontDefault,
+			Shiny:       genV.BlackWhite.FrontShiny,
+			Female:      optionalSprite(genV.BlackWhite.FrontFemale),
+			ShinyFemale: optionalSprite(genV.BlackWhite.FrontShinyFemale),
+		},
+		{
+			Generation:  6,
+			Game:        "X/Y",
+			Default:     genVI.XY.FrontDefault,
+			Shiny:       genVI.XY.FrontShiny,
+			Female:      optionalSprite(genVI.XY.FrontFemale),
+			ShinyFemale: optionalSprite(genVI.XY.FrontShinyFemale),
+		},
+		{
+			Generation:  6,
+			Game:        "Omega Ruby/Alpha Sapphire",
+			Default:     genVI.OmegarubyAlphasapphire.FrontDefault,
+			Shiny:       genVI.OmegarubyAlphasapphire.FrontShiny,
+			Female:      optionalSprite(genVI.OmegarubyAlphasapphire.FrontFemale),
+			ShinyFemale: optionalSprite(genVI.OmegarubyAlphasapphire.FrontShinyFemale),
+		},
+		{
+			Generation:  7,
+			Game:        "Ultra Sun/Ultra Moon",
+			Default:     genVII.UltraSunUltraMoon.FrontDefault,
+			Shiny:       genVII.UltraSunUltraMoon.FrontShiny,
+			Female:      optionalSprite(genVII.UltraSunUltraMoon.FrontFemale),
+			ShinyFemale: optionalSprite(genVII.UltraSunUltraMoon.FrontShinyFemale),
+		},
+	}
+
+	spriteVersions := []PokemonSpriteVersion{}
+	for _, version := range all {
+		if version.Default != "" || version.Shiny != "" || version.Female != "" || version.ShinyFemale != "" {
+			spriteVersions = append(spriteVersions, version)
+		}
+	}
+	return spriteVersions
+}
+
+// getPokemonGallery fetches and renders the sprites of the variant in every
+// game concurrently, sprites failing to load are left out.
+func getPokemonGallery(pokemon string, versions []PokemonSpriteVersion, variant int) tea.Cmd {
+	return func() tea.Msg {
+		sprites := map[string]string{}
+		var mu sync.Mutex
+		var wg sync.WaitGroup
+		for _, version := range versions {
+			url := version.URL(variant)
+			if url == "" {
+				continue
+			}
+			wg.Add(1)
+			go func(game, url string) {
+				defer wg.Done()
+				img, err := getSprite(url)
+				if err != nil {
+					return
+				}
+				sprite := renderSprite(img, galleryCellWidth-2)
+				mu.Lock()
+				sprites[game] = sprite
+				mu.Unlock()
+			}(version.Game, url)
+		}
+		wg.Wait()
+		return PokemonGalleryMsg{Pokemon: pokemon, Variant: variant, Sprites: sprites}
+	}
+}
+
+// galleryView renders the sprites in a grid fitting width, with the game
+// under each sprite.
+func (p PokedexViewModel) galleryView(width int) string {
+	variant := spriteVariants[p.Gallery.Variant]
+	header := "Sprites of " + p.Pokemon.Name + " (s - " + variant + ")"
+
+	shown := []PokemonSpriteVersion{}
+	for _, version := range p.Pokemon.SpriteVersions {
+		if _, ok := p.Gallery.Sprites[version.Game]; ok {
+			shown = append(shown, version)
+		}
+	}
+	if len(shown) == 0 {
+		return header + "\n\nNo " + variant + " sprites for " + p.Pokemon.Name
+	}
+
+	// Sprites in a row are padded to the tallest one so the games line up.
+	columns := max(1, width/galleryCellWidth)
+	rows := []string{}
+	for start := 0; start < len(shown); start += columns {
+		row := shown[start:min(start+columns, len(shown))]
+		height := 0
+		for _, version := range row {
+			height = max(height, lipgloss.Height(p.Gallery.Sprites[version.Game]))
+		}
+		cells := []string{}
+		for _, version := range row {
+			sprite := lipgloss.PlaceVertical(height, lipgloss.Bottom, p.Gallery.Sprites[version.Game])
+			cells = append(cells, lipgloss.NewStyle().Width(galleryCellWidth).Render(
+				lipgloss.JoinVertical(lipgloss.Left, sprite, version.Game, generationName(version.Generation)),
+			))
+		}
+		rows = append(rows, lipgloss.JoinHorizontal(lipgloss.Top, cells...))
+	}
+	return header + "\n\n" + strings.Join(rows, "\n\n")
+}
+
+// SetGallery displays the sprites fetched with msg.
+func (p PokedexViewModel) SetGallery(msg PokemonGalleryMsg) PokedexViewModel {
+	if msg.Pokemon != p.Gallery.Pokemon || msg.Variant != p.Gallery.Variant {
+		return p
+	}
+	p.Gallery.Sprites = msg.Sprites
+	p.Gallery.Loaded = true
+	return p
+}
@@ -0,0 +1,154 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/list"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// The list thumbnails are drawn left of the title and description of each
+// pokemon.
+const (
+	thumbnailWidth  = 5
+	thumbnailHeight = 2
+)
+
+// listDetailSlots bounds the number of pokemon details fetched at once for
+// the list, each fetch holds a slot while it runs.
+var listDetailSlots = make(chan struct{}, 4)
+
+// PokemonListDetail is what the list shows of a pokemon besides its name.
+type PokemonListDetail struct {
+	PokemonSortKeys
+	Thumbnail string
+	Loading   bool
+	Err       error
+}
+
+type PokemonListDetailMsg struct {
+	Name   string
+	Detail PokemonListDetail
+}
+
+// getPokemonListDetail fetches the details of the pokemon or species name
+// shown in the list.
+func getPokemonListDetail(name string) tea.Cmd {
+	return func() tea.Msg {
+		listDetailSlots <- struct{}{}
+		defer func() { <-listDetailSlots }()
+
+		pokemon, err := searchPokemon(name)
+		if err != nil {
+			return PokemonListDetailMsg{Name: name, Detail: PokemonListDetail{Err: err}}
+		}
+		detail := PokemonListDetail{PokemonSortKeys: newPokemonSortKeys(pokemon)}
+		if img, err := getSprite(pokemon.Sprite); err == nil {
+			detail.Thumbnail = renderThumbnail(img, thumbnailWidth, thumbnailHeight)
+		}
+		return PokemonListDetailMsg{Name: name, Detail: detail}
+	}
+}
+
+// fetchDetails fetches the details of the pokemon on screen which were not
+// fetched yet, the rest are fetched as the list scrolls to them.
+func (pl PokemonListModel) fetchDetails() tea.Cmd {
+	cmds := []tea.Cmd{}
+	items := pl.PokemonList.VisibleItems()
+	start, end := pl.PokemonList.Paginator.GetSliceBounds(len(items))
+	for _, item := range items[start:end] {
+		name := item.FilterValue()
+		if _, ok := pl.Details[name]; ok {
+			continue
+		}
+		pl.Details[name] = PokemonListDetail{Loading: true}
+		cmds = append(cmds, getPokemonListDetail(name))
+	}
+	return tea.Batch(cmds...)
+}
+
+// SetDetail stores a fetched detail and its sort keys, and updates the
+// listed pokemon. The list is sorted again only for a pokemon the index did
+// not place yet, while indexing the batches sort it.
+func (pl PokemonListModel) SetDetail(msg PokemonListDetailMsg) (PokemonListModel, tea.Cmd) {
+	pl.Details[msg.Name] = msg.Detail
+	placed := pl.SortKeys[msg.Name].Number > 0
+	if msg.Detail.Number > 0 {
+		pl.SortKeys[msg.Name] = msg.Detail.PokemonSortKeys
+	}
+	if pokemonListSorts[pl.Sort].Value != nil && !placed && !pl.Indexing {
+		return pl.setItems()
+	}
+	for i, item := range pl.PokemonList.Items() {
+		if item, ok := item.(PokemonListItem); ok && item.title == msg.Name {
+			pl.PokemonList.SetItem(i, pl.listItem(item.title, item.label))
+		}
+	}
+	return pl, nil
+}
+
+// listItem returns the item of the pokemon name, label is the regional
+// number of pokedex sources shown before the details, followed by the value
+// the list is sorted by. The sort keys of the index stand in for the
+// details not fetched yet.
+func (pl PokemonListModel) listItem(name string, label string) PokemonListItem {
+	detail := pl.Details[name]
+	keys := detail.PokemonSortKeys
+	if keys.Number == 0 {
+		keys = pl.SortKeys[name]
+	}
+	parts := []string{}
+	if label != "" {
+		parts = append(parts, label)
+	}
+	switch {
+	case detail.Err != nil:
+		parts = append(parts, errorKind(detail.Err).String())
+	case keys.Number > 0:
+		if label == "" {
+			parts = append(parts, fmt.Sprintf("#%03d", keys.Number))
+		}
+		parts = append(parts, strings.Join(keys.Types, "/"), fmt.Sprintf("BST %d", keys.Total))
+		if s := pokemonListSorts[pl.Sort]; s.Label != "" {
+			parts = append(parts, fmt.Sprintf("%s %d", s.Label, s.Value(keys)))
+		}
+	case detail.Loading:
+		parts = append(parts, "loading")
+	}
+
+	return PokemonListItem{
+		title:     name,
+		desc:      strings.Join(parts, " · "),
+		label:     label,
+		thumbnail: detail.Thumbnail,
+	}
+}
+
+// pokemonListDelegate draws the thumbnail of each pokemon left of the title
+// and description drawn by the default delegate.
+type pokemonListDelegate struct {
+	list.DefaultDelegate
+}
+
+func newPokemonListDelegate() pokemonListDelegate {
+	return pokemonListDelegate{DefaultDelegate: list.NewDefaultDelegate()}
+}
+
+func (d pokemonListDelegate) Render(w io.Writer, m list.Model, index int, item list.Item) {
+	var b strings.Builder
+	d.DefaultDelegate.Render(&b, m, index, item)
+
+	thumbnail := ""
+	if item, ok := item.(PokemonListItem); ok {
+		thumbnail = item.thumbnail
+	}
+	thumbnail = lipgloss.NewStyle().Width(thumbnailWidth).Height(thumbnailHeight).MaxHeight(thumbnailHeight).
+		AlignVertical(lipgloss.Center).Render(thumbnail)
+
+	fmt.Fprint(w, lipgloss.NewStyle().MaxWidth(m.Width()).Render(
+		lipgloss.JoinHorizontal(lipgloss.Top, thumbnail, " ", b.String()),
+	))
+}
@@ -0,0 +1,265 @@
+package main
+
+import (
+	"fmt"
+	"sort"
+	"sync"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// PokemonListSort is an order of the Pokemon List. Value returns the sorted
+// value of a pokemon from its sort keys, nil for the dex number and name
+// which are known without them.
+type PokemonListSort struct {
+	Name  string
+	Value func(PokemonSortKeys) int
+	// Label names the value in the item descriptions, empty when they
+	// already show it.
+	Label string
+}
+
+var pokemonListSorts = newPokemonListSorts()
+
+func newPokemonListSorts() []PokemonListSort {
+	sorts := []PokemonListSort{{Name: "number"}, {Name: "name"}}
+	for _, stat := range statNames {
+		sorts = append(sorts, PokemonListSort{
+			Name: stat,
+			Value: func(d PokemonSortKeys) int {
+				for _, s := range d.Stats {
+					if s.Name == stat {
+						return s.Base
+					}
+				}
+				return 0
+			},
+			Label: stat,
+		})
+	}
+	return append(sorts,
+		PokemonListSort{Name: "BST", Value: func(d PokemonSortKeys) int { return d.Total }},
+		PokemonListSort{Name: "height", Value: func(d PokemonSortKeys) int { return d.Height }, Label: "height"},
+		PokemonListSort{Name: "weight", Value: func(d PokemonSortKeys) int { return d.Weight }, Label: "weight"},
+	)
+}
+
+// sorted reports whether the list is in another order than the ascending
+// number of its source.
+func (pl PokemonListModel) sorted() bool {
+	return pl.Sort != 0 || pl.Descending
+}
+
+// sortedEntries returns the entries of the source in the order of the sort,
+// the pokemon not indexed yet come last in source order.
+func (pl PokemonListModel) sortedEntries() []PokemonListEntry {
+	entries := append([]PokemonListEntry{}, pl.Entries...)
+	s := pokemonListSorts[pl.Sort]
+	compare := func(a, b PokemonListEntry) int {
+		switch {
+		case s.Value != nil:
+			return s.Value(pl.SortKeys[a.Name]) - s.Value(pl.SortKeys[b.Name])
+		case s.Name == "name" && a.Name < b.Name:
+			return -1
+		case s.Name == "name" && a.Name > b.Name:
+			return 1
+		}
+		return a.Number - b.Number
+	}
+	sort.SliceStable(entries, func(i, j int) bool {
+		if s.Value != nil {
+			iKnown, jKnown := pl.SortKeys[entries[i].Name].Number > 0, pl.SortKeys[entries[j].Name].Number > 0
+			if iKnown != jKnown || !iKnown {
+				return iKnown
+			}
+		}
+		if pl.Descending {
+			return compare(entries[i], entries[j]) > 0
+		}
+		return compare(entries[i], entries[j]) < 0
+	})
+	return entries
+}
+
+// indexed returns the number of pokemon of the source whose sort keys were
+// looked up.
+func (pl PokemonListModel) indexed() int {
+	count := 0
+	for _, entry := range pl.Entries {
+		if _, ok := pl.SortKeys[entry.Name]; ok {
+			count++
+		}
+	}
+	return count
+}
+
+// index fetches what the sort needs of every pokemon of the source, the rest
+// of the national order then the details of each pokemon, a batch at a time.
+func (pl PokemonListModel) index() (PokemonListModel, tea.Cmd) {
+	if !pl.sorted() {
+		return pl, nil
+	}
+	if pl.Source.Kind == "" && pl.Next != "" {
+		if pl.Loading {
+			return pl, nil
+		}
+		pl.Loading = true
+		return pl, pl.fetchMore(pl.Count - len(pl.Entries))
+	}
+	if pokemonListSorts[pl.Sort].Value == nil {
+		return pl, nil
+	}
+
+	if pl.Indexing {
+		return pl, nil
+	}
+	names := []string{}
+	for _, entry := range pl.Entries {
+		if _, ok := pl.SortKeys[entry.Name]; ok {
+			continue
+		}
+		names = append(names, entry.Name)
+		if len(names) == listIndexBatch {
+			break
+		}
+	}
+	if len(names) == 0 {
+		return pl, nil
+	}
+	pl.Indexing = true
+	return pl, indexPokemonList(names)
+}
+
+// The index looks up the sort keys of listIndexBatch pokemon at a time with
+// listIndexWorkers lookups running at once, sorting the list after each
+// batch.
+const (
+	listIndexBatch   = 50
+	listIndexWorkers = 4
+)
+
+// PokemonSortKeys are what the sorts of the list need of a pokemon, kept by
+// the list for the whole source rather than the response bodies.
+type PokemonSortKeys struct {
+	Number int
+	Types  []string
+	Stats  []PokemonStat
+	Total  int
+	Height int
+	Weight int
+}
+
+func newPokemonSortKeys(pokemon Pokemon) PokemonSortKeys {
+	keys := PokemonSortKeys{
+		Number: pokemon.ID,
+		Types:  pokemon.Types,
+		Stats:  pokemon.Stats,
+		Height: pokemon.Height,
+		Weight: pokemon.Weight,
+	}
+	for _, stat := range pokemon.Stats {
+		keys.Total += stat.Base
+	}
+	return keys
+}
+
+// getPokemonSortKeys looks up the sort keys of the pokemon or species name
+// like searchPokemon, without adding the responses to the response cache
+// which the index of a whole source would fill.
+func getPokemonSortKeys(name string) (PokemonSortKeys, error) {
+	var pokemonResponse PokemonResponse
+	err := fetchJSONUncached(POKEAPI_URL+"pokemon/"+name, "pokemon", &pokemonResponse)
+	if errorKind(err) == NotFoundError {
+		var speciesResponse PokemonSpeciesResponse
+		if fetchJSONUncached(POKEAPI_URL+"pokemon-species/"+name, "pokemon species", &speciesResponse) == nil {
+			for _, variety := range speciesResponse.Varieties {
+				if variety.IsDefault {
+					err = fetchJSONUncached(POKEAPI_URL+"pokemon/"+variety.Pokemon.Name, "pokemon", &pokemonResponse)
+				}
+			}
+		}
+	}
+	if err != nil {
+		return PokemonSortKeys{}, err
+	}
+	return newPokemonSortKeys(formatPokemon(pokemonResponse)), nil
+}
+
+type PokemonListIndexMsg struct {
+	SortKeys map[string]PokemonSortKeys
+}
+
+// indexPokemonList looks up the sort keys of the pokemon names, those which
+// fail are kept empty and listed last.
+func indexPokemonList(names []string) tea.Cmd {
+	return func() tea.Msg {
+		keys := make([]PokemonSortKeys, len(names))
+		jobs := make(chan int)
+		var wg sync.WaitGroup
+		for range min(listIndexWorkers, len(names)) {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				for i := range jobs {
+					keys[i], _ = getPokemonSortKeys(names[i])
+				}
+			}()
+		}
+		for i := range names {
+			jobs <- i
+		}
+		close(jobs)
+		wg.Wait()
+
+		msg := PokemonListIndexMsg{SortKeys: map[string]PokemonSortKeys{}}
+		for i, name := range names {
+			msg.SortKeys[name] = keys[i]
+		}
+		return msg
+	}
+}
+
+// SetIndex stores a batch of the index and sorts the list once for it, then
+// indexes the next batch and fetches the details on screen.
+func (pl PokemonListModel) SetIndex(msg PokemonListIndexMsg) (PokemonListModel, tea.Cmd) {
+	pl.Indexing = false
+	for name, keys := range msg.SortKeys {
+		// The keys of a detail fetched meanwhile for the screen are kept.
+		if pl.SortKeys[name].Number == 0 {
+			pl.SortKeys[name] = keys
+		}
+	}
+	pl, cmd := pl.setItems()
+	pl, indexCmd := pl.index()
+	pl, scrollCmd := pl.scrolled(nil)
+	return pl, tea.Batch(cmd, indexCmd, scrollCmd)
+}
+
+// setSort lists the pokemon in the order of sort from the top, indexing the
+// source if needed.
+func (pl PokemonListModel) setSort(sort int, descending bool) (PokemonListModel, tea.Cmd) {
+	pl.Sort = sort
+	pl.Descending = descending
+	pl, cmd := pl.setItems()
+	pl.PokemonList.ResetSelected()
+	pl, indexCmd := pl.index()
+	pl, scrollCmd := pl.scrolled(nil)
+	return pl, tea.Batch(cmd, indexCmd, scrollCmd)
+}
+
+// sortHeader describes the order of the list for the header, empty for the
+// order of the source.
+func (pl PokemonListModel) sortHeader() string {
+	if !pl.sorted() {
+		return ""
+	}
+	direction := "↑"
+	if pl.Descending {
+		direction = "↓"
+	}
+	header := fmt.Sprintf(", by %s %s", pokemonListSorts[pl.Sort].Name, direction)
+	if indexed := pl.indexed(); pokemonListSorts[pl.Sort].Value != nil && indexed < len(pl.Entries) {
+		header += fmt.Sprintf(" (%d indexed)", indexed)
+	}
+	return header
+}
@@ -0,0 +1,182 @@
+package main
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/key"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// PokemonListSource is what the pokemon list pages through, the national
+// order by default, or a generation or regional pokedex fetched as a whole
+// and paged locally.
+type PokemonListSource struct {
+	Name string
+	// Kind is the PokeAPI endpoint of the source, "generation" or "pokedex",
+	// empty for the national order.
+	Kind string
+	ID   string
+}
+
+var pokemonListSources = []PokemonListSource{
+	{Name: "National"},
+	{Name: "Generation I", Kind: "generation", ID: "generation-i"},
+	{Name: "Generation II", Kind: "generation", ID: "generation-ii"},
+	{Name: "Generation III", Kind: "generation", ID: "generation-iii"},
+	{Name: "Generation IV", Kind: "generation", ID: "generation-iv"},
+	{Name: "Generation V", Kind: "generation", ID: "generation-v"},
+	{Name: "Generation VI", Kind: "generation", ID: "generation-vi"},
+	{Name: "Generation VII", Kind: "generation", ID: "generation-vii"},
+	{Name: "Generation VIII", Kind: "generation", ID: "generation-viii"},
+	{Name: "Generation IX", Kind: "generation", ID: "generation-ix"},
+	{Name: "Kanto", Kind: "pokedex", ID: "kanto"},
+	{Name: "Johto", Kind: "pokedex", ID: "original-johto"},
+	{Name: "Hoenn", Kind: "pokedex", ID: "hoenn"},
+	{Name: "Sinnoh", Kind: "pokedex", ID: "original-sinnoh"},
+	{Name: "Sinnoh (Platinum)", Kind: "pokedex", ID: "extended-sinnoh"},
+	{Name: "Johto (HGSS)", Kind: "pokedex", ID: "updated-johto"},
+	{Name: "Unova", Kind: "pokedex", ID: "original-unova"},
+	{Name: "Unova (B2W2)", Kind: "pokedex", ID: "updated-unova"},
+	{Name: "Kalos Central", Kind: "pokedex", ID: "kalos-central"},
+	{Name: "Kalos Coastal", Kind: "pokedex", ID: "kalos-coastal"},
+	{Name: "Kalos Mountain", Kind: "pokedex", ID: "kalos-mountain"},
+	{Name: "Hoenn (ORAS)", Kind: "pokedex", ID: "updated-hoenn"},
+	{Name: "Alola", Kind: "pokedex", ID: "original-alola"},
+	{Name: "Alola (USUM)", Kind: "pokedex", ID: "updated-alola"},
+	{Name: "Galar", Kind: "pokedex", ID: "galar"},
+	{Name: "Isle of Armor", Kind: "pokedex", ID: "isle-of-armor"},
+	{Name: "Crown Tundra", Kind: "pokedex", ID: "crown-tundra"},
+	{Name: "Hisui", Kind: "pokedex", ID: "hisui"},
+	{Name: "Paldea", Kind: "pokedex", ID: "paldea"},
+	{Name: "Kitakami", Kind: "pokedex", ID: "kitakami"},
+	{Name: "Blueberry", Kind: "pokedex", ID: "blueberry"},
+}
+
+type PokemonListSourceMsg struct {
+	Source  PokemonListSource
+	Entries []PokemonListEntry
+}
+
+// resourceID parses the id at the end of a PokeAPI resource url.
+func resourceID(url string) int {
+	parts := strings.Split(strings.TrimSuffix(url, "/"), "/")
+	id, _ := strconv.Atoi(parts[len(parts)-1])
+	return id
+}
+
+func getPokemonListSource(source PokemonListSource) ([]PokemonListEntry, error) {
+	switch source.Kind {
+	case "generation":
+		return getGenerationPokemon(source.ID)
+	case "pokedex":
+		return getRegionalPokedex(source.ID)
+	}
+	return nil, fmt.Errorf("unknown pokemon list source %s", source.Name)
+}
+
+// getGenerationPokemon fetches the species introduced in generation ordered by
+// their national number.
+func getGenerationPokemon(generation string) ([]PokemonListEntry, error) {
+	var generationResponse GenerationResponse
+	err := fetchJSON(POKEAPI_URL+"generation/"+generation, "generation", &generationResponse)
+	if err != nil {
+		return nil, err
+	}
+
+	entries := []PokemonListEntry{}
+	for _, species := range generationResponse.PokemonSpecies {
+		entries = append(entries, PokemonListEntry{
+			Name:   species.Name,
+			Number: resourceID(species.URL),
+		})
+	}
+	sort.Slice(entries, func(i, j int) bool {
+		return entries[i].Number < entries[j].Number
+	})
+	return entries, nil
+}
+
+// getRegionalPokedex fetches the species of a regional pokedex numbered by
+// their regional number.
+func getRegionalPokedex(pokedex string) ([]PokemonListEntry, error) {
+	var pokedexResponse PokedexResponse
+	err := fetchJSON(POKEAPI_URL+"pokedex/"+pokedex, "pokedex", &pokedexResponse)
+	if err != nil {
+		return nil, err
+	}
+
+	entries := []PokemonListEntry{}
+	for _, entry := range pokedexResponse.PokemonEntries {
+		entries = append(entries, PokemonListEntry{
+			Name:   entry.PokemonSpecies.Name,
+			Number: entry.EntryNumber,
+		})
+	}
+	sort.Slice(entries, func(i, j int) bool {
+		return entries[i].Number < entries[j].Number
+	})
+	return entries, nil
+}
+
+// handleSourceKey handles the keys pressed while the source picker is open.
+func (pl PokemonListModel) handleSourceKey(msg tea.KeyMsg) (PokemonListModel, tea.Cmd) {
+	k := pl.keys(false)
+	switch {
+	case key.Matches(msg, k.SourceUp):
+		if pl.SourceCursor > 0 {
+			pl.SourceCursor--
+		}
+	case key.Matches(msg, k.SourceDown):
+		if pl.SourceCursor < len(pokemonListSources)-1 {
+			pl.SourceCursor++
+		}
+	case key.Matches(msg, k.CloseSource):
+		pl.PickingSource = false
+	case key.Matches(msg, k.PickSource):
+		pl.PickingSource = false
+		source := pokemonListSources[pl.SourceCursor]
+		pl.Source = source
+		pl.Entries = nil
+		pl.Count = 0
+		pl.Next = ""
+		pl.JumpTo = 0
+		pl.Loading = true
+		pl.PokemonList.ResetFilter()
+		pl.PokemonList.SetItems(nil)
+		pl.PokemonList.ResetSelected()
+		if source.Kind == "" {
+			return pl, pl.fetchMore(pl.PageSize)
+		}
+		return pl, func() tea.Msg {
+			entries, err := getPokemonListSource(source)
+			if err != nil {
+				return PokemonErrorMsg{Route: "Pokemon List", Err: err}
+			}
+			return PokemonListSourceMsg{Source: source, Entries: entries}
+		}
+	}
+	return pl, nil
+}
+
+// sourcePickerView renders the sources that fit in height around the cursor.
+func (pl PokemonListModel) sourcePickerView(height int) string {
+	visible := max(height-2, 1)
+	start := 0
+	if pl.SourceCursor >= visible {
+		start = pl.SourceCursor - visible + 1
+	}
+	end := min(start+visible, len(pokemonListSources))
+
+	lines := []string{"Browse by (enter - select, esc - cancel)", ""}
+	for i := start; i < end; i++ {
+		if i == pl.SourceCursor {
+			lines = append(lines, "> "+pokemonListSources[i].Name)
+		} else {
+			lines = append(lines, "  "+pokemonListSources[i].Name)
+		}
+	}
+	return strings.Join(lines, "\n")
+}
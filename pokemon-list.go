@@ -1,20 +1,55 @@
 package main
 
 import (
-	"encoding/json"
 	"fmt"
-	"net/http"
-	"time"
+	"strconv"
 
 	"github.com/charmbracelet/bubbles/key"
 	"github.com/charmbracelet/bubbles/list"
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
 )
 
+// pokemonListPageSize is the number of pokemon fetched at once as the list
+// scrolls, set with the --page-size flag.
+var pokemonListPageSize = 20
+
 type PokemonListModel struct {
 	PokemonList list.Model
 	Navigation  PokemonListNavigation
 	isFocused   bool
-	Page        int
+	PageSize    int
+	// Count and Next are those of the last fetched page of the national
+	// order.
+	Count  int
+	Next   string
+	Source PokemonListSource
+	// Entries holds the pokemon of the source in its order, every pokemon
+	// of a generation or regional pokedex, the fetched pages of the
+	// national order.
+	Entries []PokemonListEntry
+	// Sort indexes pokemonListSorts, the pokemon are listed in its order.
+	Sort       int
+	Descending bool
+	// Details holds the details fetched for the listed pokemon by name.
+	Details map[string]PokemonListDetail
+	// SortKeys holds the sort keys of the pokemon indexed by name, empty
+	// for those which could not be looked up.
+	SortKeys      map[string]PokemonSortKeys
+	PickingSource bool
+	SourceCursor  int
+	// Jumping moves the keys to NumberInput, selecting the pokemon of the
+	// typed number on enter.
+	Jumping     bool
+	NumberInput textinput.Model
+	// JumpTo is the number to select once the pokemon up to it are fetched.
+	JumpTo int
+	// Loading is set while a page or a source is fetched.
+	Loading bool
+	// Indexing is set while a batch of the index is fetched.
+	Indexing bool
+	spinner  string
 }
 
 type PokemonListNavigation struct {
@@ -24,79 +59,298 @@ type PokemonListNavigation struct {
 
 type PokemonListItem struct {
 	title, desc string
+	label       string
+	thumbnail   string
 }
 
 func (i PokemonListItem) Title() string       { return i.title }
 func (i PokemonListItem) Description() string { return i.desc }
 func (i PokemonListItem) FilterValue() string { return i.title }
 
-type listKeyMap struct {
-	NextPage key.Binding
-	PrevPage key.Binding
-}
-
-func newListKeyMap() *listKeyMap {
-	return &listKeyMap{
-		PrevPage: key.NewBinding(
-			key.WithKeys("<-"),
-			key.WithHelp("<-", "Previous Page"),
-		),
-		NextPage: key.NewBinding(
-			key.WithKeys("->"),
-			key.WithHelp("->", "Next Page"),
-		),
-	}
-}
-
 func NewPokemonListModel() PokemonListModel {
 	items := []list.Item{}
-	listKeys := newListKeyMap()
-	pl := list.New(items, list.NewDefaultDelegate(), 0, 0)
+	pl := list.New(items, newPokemonListDelegate(), 0, 0)
 	pl.SetShowStatusBar(false)
 	pl.SetShowTitle(false)
-	pl.AdditionalShortHelpKeys = func() []key.Binding {
-		return []key.Binding{
-			listKeys.NextPage,
-			listKeys.PrevPage,
+	// The keys are listed by the help overlay, see keys.go.
+	pl.SetShowHelp(false)
+
+	numberInput := textinput.New()
+	numberInput.CharLimit = 5
+	numberInput.Width = 6
+	numberInput.Prompt = ""
+	numberInput.Validate = func(s string) error {
+		_, err := strconv.Atoi(s)
+		if s == "" {
+			return nil
 		}
+		return err
 	}
+
 	return PokemonListModel{
 		PokemonList: pl,
 		Navigation: PokemonListNavigation{
 			Next: struct{}{},
 			Prev: struct{}{},
 		},
-		isFocused: false,
-		Page:      0,
+		isFocused:   false,
+		PageSize:    pokemonListPageSize,
+		Source:      pokemonListSources[0],
+		Details:     map[string]PokemonListDetail{},
+		SortKeys:    map[string]PokemonSortKeys{},
+		NumberInput: numberInput,
 	}
 }
 
-func getPokemonList(page int) (PokemonList, error) {
-	offset := 20 * page
-	POKEMON_API := fmt.Sprintf(`https://pokeapi.co/api/v2/pokemon/?offset=%d&limit=20`, offset)
+func init() {
+	registerPaletteAction(PaletteAction{
+		Title: "Browse by generation or pokedex",
+		Route: "Pokemon List",
+		Run: func(m Model) (Model, tea.Cmd) {
+			m, cmd := m.focusRoute("Pokemon List")
+			m.PokemonList.PickingSource = true
+			return m, cmd
+		},
+	})
+}
 
-	c := http.Client{
-		Timeout: time.Second * 10,
+// fetchMore fetches up to limit pokemon of the national order after the
+// fetched ones.
+func (pl PokemonListModel) fetchMore(limit int) tea.Cmd {
+	offset := len(pl.Entries)
+	return func() tea.Msg {
+		l, err := getPokemonList(offset, limit)
+		if err != nil {
+			return PokemonErrorMsg{Route: "Pokemon List", Err: err}
+		}
+		return PokemonListMsg{Offset: offset, PokemonList: l}
 	}
+}
 
-	resp, err := c.Get(POKEMON_API)
-	if err != nil {
-		return PokemonList{}, err
+// AppendPage lists a fetched page of the national order with the fetched
+// pokemon, pages fetched for a previous source are dropped.
+func (pl PokemonListModel) AppendPage(msg PokemonListMsg) (PokemonListModel, tea.Cmd) {
+	if pl.Source.Kind != "" || msg.Offset != len(pl.Entries) {
+		return pl, nil
+	}
+
+	pl.Loading = false
+	pl.Count = msg.PokemonList.Count
+	pl.Next = msg.PokemonList.Next
+	for i, name := range msg.PokemonList.Results {
+		pl.Entries = append(pl.Entries, PokemonListEntry{Name: name, Number: msg.Offset + i + 1})
+	}
+	pl, cmd := pl.setItems()
+	if pl.JumpTo > 0 {
+		pl.selectEntry(min(pl.JumpTo, len(pl.Entries)) - 1)
+		pl.JumpTo = 0
+	}
+	pl, indexCmd := pl.index()
+	pl, scrollCmd := pl.scrolled(nil)
+	return pl, tea.Batch(cmd, indexCmd, scrollCmd)
+}
+
+// showEntries lists every pokemon of the generation or regional pokedex
+// source.
+func (pl PokemonListModel) showEntries() (PokemonListModel, tea.Cmd) {
+	pl, cmd := pl.setItems()
+	pl.PokemonList.ResetSelected()
+	pl, indexCmd := pl.index()
+	pl, scrollCmd := pl.scrolled(nil)
+	return pl, tea.Batch(cmd, indexCmd, scrollCmd)
+}
+
+// setItems lists the entries in the order of the sort, keeping the selected
+// pokemon selected unless the cursor is on the top of the list.
+func (pl PokemonListModel) setItems() (PokemonListModel, tea.Cmd) {
+	selected := ""
+	if item := pl.PokemonList.SelectedItem(); item != nil && pl.PokemonList.Index() > 0 {
+		selected = item.FilterValue()
+	}
+
+	entries := pl.sortedEntries()
+	items := make([]list.Item, 0, len(entries))
+	for _, entry := range entries {
+		items = append(items, pl.listItem(entry.Name, pl.entryLabel(entry)))
+	}
+	cmd := pl.PokemonList.SetItems(items)
+	if selected != "" && pl.PokemonList.FilterState() == list.Unfiltered {
+		pl.selectName(selected)
+	}
+	return pl, cmd
+}
+
+// entryLabel returns the number shown for the pokemon of generation and
+// regional pokedex sources, the national number comes with its details.
+func (pl PokemonListModel) entryLabel(entry PokemonListEntry) string {
+	switch pl.Source.Kind {
+	case "generation":
+		return fmt.Sprintf("#%03d", entry.Number)
+	case "pokedex":
+		return fmt.Sprintf("%s #%03d", pl.Source.Name, entry.Number)
+	}
+	return ""
+}
+
+// selectName selects the pokemon name in the list.
+func (pl *PokemonListModel) selectName(name string) {
+	for i, item := range pl.PokemonList.Items() {
+		if item.FilterValue() == name {
+			pl.PokemonList.Select(i)
+			return
+		}
+	}
+}
+
+// selectEntry selects the pokemon at index of the source order.
+func (pl *PokemonListModel) selectEntry(index int) {
+	pl.selectName(pl.Entries[index].Name)
+}
+
+// updateList updates the list with msg, which may scroll it.
+func (pl PokemonListModel) updateList(msg tea.Msg) (PokemonListModel, tea.Cmd) {
+	var cmd tea.Cmd
+	pl.PokemonList, cmd = pl.PokemonList.Update(msg)
+	return pl.scrolled(cmd)
+}
+
+// scrolled fetches the details of the pokemon on screen, and the next page
+// of the national order once the cursor is within a screen of the end of
+// the list.
+func (pl PokemonListModel) scrolled(cmd tea.Cmd) (PokemonListModel, tea.Cmd) {
+	cmds := []tea.Cmd{cmd, pl.fetchDetails()}
+	remaining := len(pl.PokemonList.Items()) - pl.PokemonList.Index()
+	if pl.Source.Kind == "" && !pl.sorted() && !pl.Loading && pl.Next != "" &&
+		pl.PokemonList.FilterState() == list.Unfiltered && remaining <= pl.PokemonList.Paginator.PerPage {
+		pl.Loading = true
+		cmds = append(cmds, pl.fetchMore(pl.PageSize))
+	}
+	return pl, tea.Batch(cmds...)
+}
+
+// total returns the number of pokemon of the source, 0 while unknown.
+func (pl PokemonListModel) total() int {
+	if pl.Source.Kind != "" {
+		return len(pl.Entries)
+	}
+	return pl.Count
+}
+
+// position describes where the cursor is in the list, within the matches
+// while filtered.
+func (pl PokemonListModel) position() string {
+	total := pl.total()
+	if pl.PokemonList.FilterState() != list.Unfiltered {
+		total = len(pl.PokemonList.VisibleItems())
+	}
+	if total == 0 {
+		return "no pokemon"
 	}
-	defer resp.Body.Close()
+	return fmt.Sprintf("#%d of %d", pl.PokemonList.Index()+1, total)
+}
 
-	if resp.StatusCode != 200 {
-		if resp.StatusCode == 404 {
-			return PokemonList{}, fmt.Errorf("Pokemon list not found")
-		} else if resp.StatusCode == 429 {
-			return PokemonList{}, fmt.Errorf("too many requests")
+// jump selects the pokemon of number in the source order, fetching the
+// national order up to it if needed.
+func (pl PokemonListModel) jump(number int) (PokemonListModel, tea.Cmd) {
+	number = min(max(number, 1), max(pl.total(), 1))
+	pl.PokemonList.ResetFilter()
+	if number <= len(pl.Entries) {
+		pl.selectEntry(number - 1)
+		return pl.scrolled(nil)
+	}
+	if pl.Source.Kind != "" || pl.Loading {
+		return pl, nil
+	}
+	pl.JumpTo = number
+	pl.Loading = true
+	return pl, pl.fetchMore(number - len(pl.Entries) + pl.PageSize)
+}
+
+// handleJumpKey handles the keys pressed while typing a number to go to.
+func (pl PokemonListModel) handleJumpKey(msg tea.KeyMsg) (PokemonListModel, tea.Cmd) {
+	k := pl.keys(false)
+	switch {
+	case key.Matches(msg, k.CancelJump):
+		pl.Jumping = false
+		pl.NumberInput.Blur()
+		return pl, nil
+	case key.Matches(msg, k.JumpTo):
+		pl.Jumping = false
+		pl.NumberInput.Blur()
+		number, err := strconv.Atoi(pl.NumberInput.Value())
+		if err != nil {
+			return pl, nil
 		}
-		return PokemonList{}, fmt.Errorf("status code: %d", resp.StatusCode)
+		return pl.jump(number)
 	}
 
+	var cmd tea.Cmd
+	pl.NumberInput, cmd = pl.NumberInput.Update(msg)
+	return pl, cmd
+}
+
+// startJump opens the number input.
+func (pl PokemonListModel) startJump() (PokemonListModel, tea.Cmd) {
+	pl.Jumping = true
+	pl.NumberInput.SetValue("")
+	return pl, pl.NumberInput.Focus()
+}
+
+func (pl PokemonListModel) View() string {
+	if pl.PickingSource {
+		return lipgloss.NewStyle().Height(pl.PokemonList.Height()).Render(pl.sourcePickerView(pl.PokemonList.Height()))
+	}
+	return pl.PokemonList.View()
+}
+
+func (pl PokemonListModel) Header() string {
+	if pl.Jumping {
+		return fmt.Sprintf("%s - go to # (1-%d): %s", pl.Source.Name, max(pl.total(), 1), pl.NumberInput.View())
+	}
+	header := fmt.Sprintf("%s - %s%s (s - browse by)", pl.Source.Name, pl.position(), pl.sortHeader())
+	if pl.Source.Kind == "" {
+		header = fmt.Sprintf("%s - %s, %d loaded%s (s - browse by)", pl.Source.Name, pl.position(), len(pl.Entries), pl.sortHeader())
+	}
+	if pl.Loading {
+		header += " " + pl.spinner
+	}
+	return header
+}
+
+// handleMouse handles mouse presses on the pokemon list drawn in body,
+// clicking a pokemon opens it in the pokedex and the wheel moves the cursor.
+func (pl PokemonListModel) handleMouse(msg tea.MouseMsg, body Rect) (PokemonListModel, tea.Cmd) {
+	key, isWheel := wheelKey(msg)
+	if pl.PickingSource {
+		if isWheel {
+			return pl.handleSourceKey(key)
+		}
+		return pl, nil
+	}
+	if isWheel {
+		return pl.updateList(key)
+	}
+	if msg.Button != tea.MouseButtonLeft {
+		return pl, nil
+	}
+
+	index, ok := listItemAt(pl.PokemonList, msg.Y-body.Y, newPokemonListDelegate())
+	if !ok {
+		return pl, nil
+	}
+	pl.PokemonList.Select(index)
+	name := pl.PokemonList.SelectedItem().FilterValue()
+	return pl, func() tea.Msg {
+		return OpenPokemonMsg{Name: name}
+	}
+}
+
+// getPokemonList fetches limit pokemon of the national order from offset.
+func getPokemonList(offset int, limit int) (PokemonList, error) {
+	url := fmt.Sprintf("%spokemon/?offset=%d&limit=%d", POKEAPI_URL, offset, limit)
+
 	var pokemonResponse PokemonListResponse
-	err = json.NewDecoder(resp.Body).Decode(&pokemonResponse)
-	if err != nil {
+	if err := fetchJSON(url, "Pokemon list", &pokemonResponse); err != nil {
 		return PokemonList{}, err
 	}
 
@@ -110,12 +364,21 @@ func formatPokemonList(pokemonListResponse PokemonListResponse) PokemonList {
 		PokemonListResults = append(PokemonListResults, pokemon.Name)
 	}
 
-	return PokemonList{
+	pokemonList := PokemonList{
 		Count:   pokemonListResponse.Count,
 		Results: PokemonListResults,
 	}
+	if pokemonListResponse.Next != nil {
+		pokemonList.Next = *pokemonListResponse.Next
+	}
+	if pokemonListResponse.Previous != nil {
+		pokemonList.Previous = *pokemonListResponse.Previous
+	}
+	return pokemonList
 }
 
+// PokemonListMsg is a page of the national order fetched from Offset.
 type PokemonListMsg struct {
+	Offset      int
 	PokemonList PokemonList
 }
@@ -273,26 +273,343 @@ type PokemonResponse struct {
 }
 
 type Pokemon struct {
-	Name      string
-	Height    int
-	Weight    int
-	Types     []string
-	Abilities []string
+	ID                     int
+	Name                   string
+	Height                 int
+	Weight                 int
+	Types                  []string
+	Abilities              []string
+	LocationAreaEncounters string
+	HeldItems              []PokemonHeldItem
+	Species                string
+	Forms                  []string
+	Stats                  []PokemonStat
+	Sprite                 string
+	PastTypes              []PokemonPastTypes
+	CryLatest              string
+	CryLegacy              string
+	SpriteVersions         []PokemonSpriteVersion
+}
+
+// PokemonPastTypes are the types a pokemon had up to and including
+// Generation.
+type PokemonPastTypes struct {
+	Generation int
+	Types      []string
+}
+
+type PokemonStat struct {
+	Name string
+	Base int
+}
+
+type PokemonHeldItem struct {
+	Name     string
+	Rarities []PokemonHeldItemRarity
+}
+
+type PokemonHeldItemRarity struct {
+	Version string
+	Rarity  int
 }
 
 type PokemonListResponse struct {
-	Count    int         `json:"count"`
-	Next     interface{} `json:"next"`
-	Previous interface{} `json:"previous"`
+	Count    int     `json:"count"`
+	Next     *string `json:"next"`
+	Previous *string `json:"previous"`
 	Results  []struct {
 		Name string `json:"name"`
 		URL  string `json:"url"`
 	} `json:"results"`
 }
 
+// PokemonList is a page of a PokeAPI list, Next and Previous are the urls of
+// the neighbouring pages, empty at the ends of the list.
 type PokemonList struct {
 	Count    int
-	Next     interface{}
-	Previous interface{}
+	Next     string
+	Previous string
 	Results  []string
 }
+
+type PokemonEncounterResponse []struct {
+	LocationArea struct {
+		Name string `json:"name"`
+		URL  string `json:"url"`
+	} `json:"location_area"`
+	VersionDetails []struct {
+		MaxChance        int `json:"max_chance"`
+		EncounterDetails []struct {
+			MinLevel        int `json:"min_level"`
+			MaxLevel        int `json:"max_level"`
+			Chance          int `json:"chance"`
+			ConditionValues []struct {
+				Name string `json:"name"`
+				URL  string `json:"url"`
+			} `json:"condition_values"`
+			Method struct {
+				Name string `json:"name"`
+				URL  string `json:"url"`
+			} `json:"method"`
+		} `json:"encounter_details"`
+		Version struct {
+			Name string `json:"name"`
+			URL  string `json:"url"`
+		} `json:"version"`
+	} `json:"version_details"`
+}
+
+type PokemonEncounterVersion struct {
+	Version string
+	Areas   []PokemonEncounterArea
+}
+
+type PokemonEncounterArea struct {
+	Name      string
+	MaxChance int
+	Details   []PokemonEncounterDetail
+}
+
+type PokemonEncounterDetail struct {
+	Method     string
+	MinLevel   int
+	MaxLevel   int
+	Chance     int
+	Conditions []string
+}
+
+type ItemResponse struct {
+	ID       int    `json:"id"`
+	Name     string `json:"name"`
+	Cost     int    `json:"cost"`
+	Category struct {
+		Name string `json:"name"`
+		URL  string `json:"url"`
+	} `json:"category"`
+	EffectEntries []struct {
+		Effect      string `json:"effect"`
+		ShortEffect string `json:"short_effect"`
+		Language    struct {
+			Name string `json:"name"`
+			URL  string `json:"url"`
+		} `json:"language"`
+	} `json:"effect_entries"`
+	Sprites struct {
+		Default string `json:"default"`
+	} `json:"sprites"`
+	HeldByPokemon []struct {
+		Pokemon struct {
+			Name string `json:"name"`
+			URL  string `json:"url"`
+		} `json:"pokemon"`
+	} `json:"held_by_pokemon"`
+}
+
+type Item struct {
+	Name        string
+	Category    string
+	Cost        int
+	Effect      string
+	ShortEffect string
+	Sprite      string
+	HeldBy      []string
+}
+
+type MoveResponse struct {
+	ID           int    `json:"id"`
+	Name         string `json:"name"`
+	Accuracy     *int   `json:"accuracy"`
+	EffectChance *int   `json:"effect_chance"`
+	PP           *int   `json:"pp"`
+	Priority     int    `json:"priority"`
+	Power        *int   `json:"power"`
+	DamageClass  struct {
+		Name string `json:"name"`
+		URL  string `json:"url"`
+	} `json:"damage_class"`
+	Type struct {
+		Name string `json:"name"`
+		URL  string `json:"url"`
+	} `json:"type"`
+	EffectEntries []struct {
+		Effect      string `json:"effect"`
+		ShortEffect string `json:"short_effect"`
+		Language    struct {
+			Name string `json:"name"`
+			URL  string `json:"url"`
+		} `json:"language"`
+	} `json:"effect_entries"`
+	LearnedByPokemon []struct {
+		Name string `json:"name"`
+		URL  string `json:"url"`
+	} `json:"learned_by_pokemon"`
+}
+
+type Move struct {
+	Name         string
+	Type         string
+	DamageClass  string
+	Power        int
+	Accuracy     int
+	PP           int
+	Priority     int
+	EffectChance int
+	ShortEffect  string
+	LearnedBy    []string
+}
+
+type BerryResponse struct {
+	ID               int    `json:"id"`
+	Name             string `json:"name"`
+	GrowthTime       int    `json:"growth_time"`
+	MaxHarvest       int    `json:"max_harvest"`
+	NaturalGiftPower int    `json:"natural_gift_power"`
+	Size             int    `json:"size"`
+	Smoothness       int    `json:"smoothness"`
+	SoilDryness      int    `json:"soil_dryness"`
+	Firmness         struct {
+		Name string `json:"name"`
+		URL  string `json:"url"`
+	} `json:"firmness"`
+	Flavors []struct {
+		Potency int `json:"potency"`
+		Flavor  struct {
+			Name string `json:"name"`
+			URL  string `json:"url"`
+		} `json:"flavor"`
+	} `json:"flavors"`
+	Item struct {
+		Name string `json:"name"`
+		URL  string `json:"url"`
+	} `json:"item"`
+	NaturalGiftType struct {
+		Name string `json:"name"`
+		URL  string `json:"url"`
+	} `json:"natural_gift_type"`
+}
+
+type BerryFlavorResponse struct {
+	ID      int    `json:"id"`
+	Name    string `json:"name"`
+	Berries []struct {
+		Potency int `json:"potency"`
+		Berry   struct {
+			Name string `json:"name"`
+			URL  string `json:"url"`
+		} `json:"berry"`
+	} `json:"berries"`
+	ContestType struct {
+		Name string `json:"name"`
+		URL  string `json:"url"`
+	} `json:"contest_type"`
+}
+
+type BerryFirmnessResponse struct {
+	ID      int    `json:"id"`
+	Name    string `json:"name"`
+	Berries []struct {
+		Name string `json:"name"`
+		URL  string `json:"url"`
+	} `json:"berries"`
+}
+
+type GenerationResponse struct {
+	ID             int    `json:"id"`
+	Name           string `json:"name"`
+	PokemonSpecies []struct {
+		Name string `json:"name"`
+		URL  string `json:"url"`
+	} `json:"pokemon_species"`
+}
+
+type PokedexResponse struct {
+	ID             int    `json:"id"`
+	Name           string `json:"name"`
+	PokemonEntries []struct {
+		EntryNumber    int `json:"entry_number"`
+		PokemonSpecies struct {
+			Name string `json:"name"`
+			URL  string `json:"url"`
+		} `json:"pokemon_species"`
+	} `json:"pokemon_entries"`
+}
+
+type PokemonListEntry struct {
+	Name   string
+	Number int
+}
+
+type PokemonSpeciesResponse struct {
+	ID          int    `json:"id"`
+	Name        string `json:"name"`
+	IsLegendary bool   `json:"is_legendary"`
+	IsMythical  bool   `json:"is_mythical"`
+	Generation  struct {
+		Name string `json:"name"`
+		URL  string `json:"url"`
+	} `json:"generation"`
+	EvolutionChain struct {
+		URL string `json:"url"`
+	} `json:"evolution_chain"`
+	Varieties []struct {
+		IsDefault bool `json:"is_default"`
+		Pokemon   struct {
+			Name string `json:"name"`
+			URL  string `json:"url"`
+		} `json:"pokemon"`
+	} `json:"varieties"`
+}
+
+type PokemonFormResponse struct {
+	ID       int    `json:"id"`
+	Name     string `json:"name"`
+	FormName string `json:"form_name"`
+	IsMega   bool   `json:"is_mega"`
+	Pokemon  struct {
+		Name string `json:"name"`
+		URL  string `json:"url"`
+	} `json:"pokemon"`
+	Types []struct {
+		Slot int `json:"slot"`
+		Type struct {
+			Name string `json:"name"`
+			URL  string `json:"url"`
+		} `json:"type"`
+	} `json:"types"`
+	Sprites struct {
+		FrontDefault string `json:"front_default"`
+		FrontShiny   string `json:"front_shiny"`
+	} `json:"sprites"`
+}
+
+type PokemonForm struct {
+	Name    string
+	Pokemon string
+	Types   []string
+	Sprite  string
+}
+
+type TypeResponse struct {
+	ID      int    `json:"id"`
+	Name    string `json:"name"`
+	Pokemon []struct {
+		Slot    int `json:"slot"`
+		Pokemon struct {
+			Name string `json:"name"`
+			URL  string `json:"url"`
+		} `json:"pokemon"`
+	} `json:"pokemon"`
+}
+
+type EvolutionChainResponse struct {
+	ID    int                `json:"id"`
+	Chain EvolutionChainLink `json:"chain"`
+}
+
+type EvolutionChainLink struct {
+	Species struct {
+		Name string `json:"name"`
+		URL  string `json:"url"`
+	} `json:"species"`
+	EvolvesTo []EvolutionChainLink `json:"evolves_to"`
+}
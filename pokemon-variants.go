@@ -0,0 +1,197 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+type PokemonSpeciesMsg struct {
+	Species   string
+	Varieties []string
+	Err       error
+}
+
+type PokemonFormMsg struct {
+	Form PokemonForm
+	Err  error
+}
+
+type PokemonSpriteMsg struct {
+	URL    string
+	Sprite string
+}
+
+// PokedexVariants holds the varieties of the displayed pokemon species, the
+// forms are those of the displayed variety.
+type PokedexVariants struct {
+	Species   string
+	Varieties []string
+	Loaded    bool
+	Cursor    int
+}
+
+type PokedexVariant struct {
+	Name string
+	// IsForm marks a pokemon form, which only changes the types and sprite
+	// of the variety it belongs to.
+	IsForm bool
+}
+
+func getPokemonSpecies(name string) (PokemonSpeciesResponse, error) {
+	var speciesResponse PokemonSpeciesResponse
+	err := fetchJSON(POKEAPI_URL+"pokemon-species/"+name, "pokemon species", &speciesResponse)
+	return speciesResponse, err
+}
+
+// getPokemonVarieties fetches the names of the pokemon varieties of species,
+// the default variety first.
+func getPokemonVarieties(species string) ([]string, error) {
+	speciesResponse, err := getPokemonSpecies(species)
+	if err != nil {
+		return nil, err
+	}
+
+	varieties := []string{}
+	for _, variety := range speciesResponse.Varieties {
+		if variety.IsDefault {
+			varieties = append([]string{variety.Pokemon.Name}, varieties...)
+		} else {
+			varieties = append(varieties, variety.Pokemon.Name)
+		}
+	}
+	return varieties, nil
+}
+
+func getPokemonForm(name string) (PokemonForm, error) {
+	var formResponse PokemonFormResponse
+	err := fetchJSON(POKEAPI_URL+"pokemon-form/"+name, "pokemon form", &formResponse)
+	if err != nil {
+		return PokemonForm{}, err
+	}
+
+	types := []string{}
+	for _, formType := range formResponse.Types {
+		types = append(types, formType.Type.Name)
+	}
+
+	return PokemonForm{
+		Name:    formResponse.Name,
+		Pokemon: formResponse.Pokemon.Name,
+		Types:   types,
+		Sprite:  formResponse.Sprites.FrontDefault,
+	}, nil
+}
+
+// searchPokemon fetches the pokemon named name, falling back to the default
+// variety of the species named name when not found, as some species such as
+// deoxys have no pokemon of the same name.
+func searchPokemon(name string) (Pokemon, error) {
+	pokemon, err := getPokemon(name)
+	if errorKind(err) != NotFoundError {
+		return pokemon, err
+	}
+
+	varieties, speciesErr := getPokemonVarieties(name)
+	if speciesErr != nil || len(varieties) == 0 {
+		return Pokemon{}, err
+	}
+	return getPokemon(varieties[0])
+}
+
+func getPokemonSprite(url string) tea.Cmd {
+	return func() tea.Msg {
+		img, err := getSprite(url)
+		if err != nil {
+			return PokemonSpriteMsg{URL: url}
+		}
+		return PokemonSpriteMsg{URL: url, Sprite: renderSprite(img, 40)}
+	}
+}
+
+// Entries lists the varieties of the species followed by the forms of the
+// displayed variety which are not varieties themselves.
+func (v PokedexVariants) Entries(pokemon Pokemon) []PokedexVariant {
+	entries := []PokedexVariant{}
+	isVariety := map[string]bool{}
+	for _, variety := range v.Varieties {
+		isVariety[variety] = true
+		entries = append(entries, PokedexVariant{Name: variety})
+	}
+	if len(pokemon.Forms) > 1 {
+		for _, form := range pokemon.Forms {
+			if !isVariety[form] {
+				entries = append(entries, PokedexVariant{Name: form, IsForm: true})
+			}
+		}
+	}
+	return entries
+}
+
+func (p PokedexViewModel) variantsView() string {
+	entries := p.Variants.Entries(p.Pokemon)
+	if len(entries) <= 1 {
+		return p.Pokemon.Species + " has no other varieties or forms"
+	}
+
+	lines := []string{fmt.Sprintf("Varieties and forms of %s (enter - select)", p.Pokemon.Species), ""}
+	for i, entry := range entries {
+		cursor := "  "
+		if i == p.Variants.Cursor {
+			cursor = "> "
+		}
+		line := cursor + entry.Name
+		if entry.IsForm {
+			line += " (form)"
+		}
+		if entry.Name == p.Pokemon.Name {
+			line += " *"
+		}
+		lines = append(lines, line)
+	}
+	return strings.Join(lines, "\n")
+}
+
+// selectVariant fetches the variant under the cursor.
+func (p PokedexViewModel) selectVariant() tea.Cmd {
+	entries := p.Variants.Entries(p.Pokemon)
+	if len(entries) == 0 {
+		return nil
+	}
+
+	entry := entries[p.Variants.Cursor]
+	if entry.IsForm {
+		return func() tea.Msg {
+			form, err := getPokemonForm(entry.Name)
+			return PokemonFormMsg{Form: form, Err: err}
+		}
+	}
+	return func() tea.Msg {
+		pokemon, err := getPokemon(entry.Name)
+		if err != nil {
+			return PokemonErrorMsg{Route: "Pokedex", Err: err}
+		}
+		return PokemonMsg{Pokemon: pokemon}
+	}
+}
+
+// SetForm displays the displayed variety in form, which overrides its types
+// and sprite. A failed fetch keeps the variants listed, its error is shown as
+// a toast.
+func (p PokedexViewModel) SetForm(msg PokemonFormMsg) (PokedexViewModel, tea.Cmd) {
+	p.Loading = false
+	if msg.Err != nil {
+		return p, nil
+	}
+
+	pokemon := p.Pokemon
+	pokemon.Name = msg.Form.Name
+	if len(msg.Form.Types) > 0 {
+		pokemon.Types = msg.Form.Types
+	}
+	if msg.Form.Sprite != "" {
+		pokemon.Sprite = msg.Form.Sprite
+	}
+	return p.SetPokemon(pokemon)
+}
@@ -0,0 +1,339 @@
+package main
+
+import (
+	"fmt"
+	"math/rand"
+	"strconv"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/key"
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// nationalDexGenerations holds the last national number of each generation.
+var nationalDexGenerations = []int{151, 251, 386, 493, 649, 721, 809, 905, 1025}
+
+// generationOfID returns the generation that introduced the national number
+// id, 0 if id is past the last known generation.
+func generationOfID(id int) int {
+	for i, last := range nationalDexGenerations {
+		if id <= last {
+			return i + 1
+		}
+	}
+	return 0
+}
+
+type QuizModel struct {
+	Input      textinput.Model
+	Pokemon    Pokemon
+	Silhouette string
+	Sprite     string
+	Loading    bool
+	Err        error
+	Revealed   bool
+	Hints      int
+	Message    string
+	Scores     QuizScores
+	// ScoresLoaded is set once the scores are loaded, when the route is
+	// first focused. They are not saved before so a failed load does not
+	// overwrite the config file.
+	ScoresLoaded  bool
+	ScoresLoading bool
+	// ScoresErr is the error of loading or saving the scores, shown below
+	// them.
+	ScoresErr error
+	isFocused bool
+	spinner   string
+}
+
+// QuizScores are persisted in the config directory between runs.
+type QuizScores struct {
+	Streak     int `json:"streak"`
+	BestStreak int `json:"best_streak"`
+	Correct    int `json:"correct"`
+	Played     int `json:"played"`
+}
+
+const quizScoresFile = "quiz.json"
+
+// QuizScoresMsg reports the scores loaded from the config directory.
+type QuizScoresMsg struct {
+	Scores QuizScores
+	Err    error
+}
+
+// QuizScoresSavedMsg reports the error of saving the scores.
+type QuizScoresSavedMsg struct {
+	Err error
+}
+
+type QuizPokemonMsg struct {
+	Pokemon    Pokemon
+	Silhouette string
+	Sprite     string
+	Err        error
+}
+
+func NewQuizModel() QuizModel {
+	ti := textinput.New()
+	ti.Placeholder = "Who's that pokemon?"
+	ti.CharLimit = 64
+
+	return QuizModel{
+		Input: ti,
+	}
+}
+
+// loadScores loads the scores unless they are loaded or loading.
+func (q QuizModel) loadScores() (QuizModel, tea.Cmd) {
+	if q.ScoresLoaded || q.ScoresLoading {
+		return q, nil
+	}
+	q.ScoresLoading = true
+	return q, func() tea.Msg {
+		var scores QuizScores
+		if err := loadConfig(quizScoresFile, &scores); err != nil {
+			return QuizScoresMsg{Err: fmt.Errorf("could not load quiz scores: %w", err)}
+		}
+		return QuizScoresMsg{Scores: scores}
+	}
+}
+
+// getQuizPokemon picks a random pokemon and renders its sprite and
+// silhouette.
+func getQuizPokemon() tea.Msg {
+	id := rand.Intn(nationalDexGenerations[len(nationalDexGenerations)-1]) + 1
+	pokemon, err := getPokemon(strconv.Itoa(id))
+	if err != nil {
+		return QuizPokemonMsg{Err: err}
+	}
+	img, err := getSprite(pokemon.Sprite)
+	if err != nil {
+		return QuizPokemonMsg{Err: err}
+	}
+	return QuizPokemonMsg{
+		Pokemon:    pokemon,
+		Silhouette: renderSilhouette(img, 48),
+		Sprite:     renderSprite(img, 48),
+	}
+}
+
+// saveScores saves the scores unless they are not loaded.
+func (q QuizModel) saveScores() tea.Cmd {
+	if !q.ScoresLoaded {
+		return nil
+	}
+	scores := q.Scores
+	return func() tea.Msg {
+		if err := saveConfig(quizScoresFile, scores); err != nil {
+			return QuizScoresSavedMsg{Err: fmt.Errorf("could not save quiz scores: %w", err)}
+		}
+		return QuizScoresSavedMsg{}
+	}
+}
+
+// Next starts a new round.
+func (q QuizModel) Next() (QuizModel, tea.Cmd) {
+	q.Loading = true
+	q.Revealed = false
+	q.Hints = 0
+	q.Message = ""
+	q.Err = nil
+	q.Input.SetValue("")
+	return q, getQuizPokemon
+}
+
+func init() {
+	registerPaletteAction(PaletteAction{
+		Title: "Skip to the next pokemon",
+		Route: "Quiz",
+		Run: func(m Model) (Model, tea.Cmd) {
+			m, cmd := m.focusRoute("Quiz")
+			if m.Quiz.Loading {
+				return m, cmd
+			}
+			var quizCmd tea.Cmd
+			m.Quiz, quizCmd = m.Quiz.Next()
+			return m, tea.Batch(cmd, quizCmd)
+		},
+	})
+}
+
+func normalizeGuess(s string) string {
+	var b strings.Builder
+	for _, r := range strings.ToLower(s) {
+		if (r >= 'a' && r <= 'z') || (r >= '0' && r <= '9') {
+			b.WriteRune(r)
+		}
+	}
+	return b.String()
+}
+
+func levenshtein(a string, b string) int {
+	ra, rb := []rune(a), []rune(b)
+	prev := make([]int, len(rb)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+	for i := 1; i <= len(ra); i++ {
+		cur := make([]int, len(rb)+1)
+		cur[0] = i
+		for j := 1; j <= len(rb); j++ {
+			cost := 1
+			if ra[i-1] == rb[j-1] {
+				cost = 0
+			}
+			cur[j] = min(prev[j]+1, cur[j-1]+1, prev[j-1]+cost)
+		}
+		prev = cur
+	}
+	return prev[len(rb)]
+}
+
+// matchGuess reports whether guess names the pokemon, allowing a typo for
+// every five letters of the name.
+func matchGuess(guess string, pokemon Pokemon) bool {
+	guess = normalizeGuess(guess)
+	if guess == "" {
+		return false
+	}
+	for _, name := range []string{pokemon.Species, pokemon.Name} {
+		name = normalizeGuess(name)
+		if name != "" && levenshtein(guess, name) <= max(1, len(name)/5) {
+			return true
+		}
+	}
+	return false
+}
+
+func (q QuizModel) Update(msg tea.Msg) (QuizModel, tea.Cmd) {
+	var cmd tea.Cmd
+
+	switch msg := msg.(type) {
+	case QuizPokemonMsg:
+		q.Loading = false
+		q.Err = msg.Err
+		q.Pokemon = msg.Pokemon
+		q.Silhouette = msg.Silhouette
+		q.Sprite = msg.Sprite
+		return q, nil
+
+	case QuizScoresMsg:
+		q.ScoresLoading = false
+		q.ScoresErr = msg.Err
+		if msg.Err == nil {
+			q.ScoresLoaded = true
+			q.Scores = msg.Scores
+		}
+		return q, nil
+
+	case QuizScoresSavedMsg:
+		q.ScoresErr = msg.Err
+		return q, nil
+
+	case tea.KeyMsg:
+		k := q.keys(false)
+		switch {
+		case key.Matches(msg, k.Next):
+			return q.Next()
+		case key.Matches(msg, k.Guess):
+			if q.Loading {
+				return q, nil
+			}
+			guess := q.Input.Value()
+			if strings.TrimSpace(guess) == "" {
+				q.Hints = min(q.Hints+1, 2)
+				return q, nil
+			}
+			q.Input.SetValue("")
+			if !matchGuess(guess, q.Pokemon) {
+				q.Message = fmt.Sprintf("Not %s, try again (esc - give up)", guess)
+				return q, nil
+			}
+			q.Revealed = true
+			q.Message = fmt.Sprintf("It's %s! (enter - next)", q.Pokemon.Species)
+			q.Scores.Streak++
+			q.Scores.Correct++
+			q.Scores.Played++
+			q.Scores.BestStreak = max(q.Scores.BestStreak, q.Scores.Streak)
+			return q, q.saveScores()
+		case key.Matches(msg, k.GiveUp):
+			if q.Loading {
+				return q, nil
+			}
+			q.Revealed = true
+			q.Message = fmt.Sprintf("It was %s (enter - next)", q.Pokemon.Species)
+			q.Scores.Streak = 0
+			q.Scores.Played++
+			return q, q.saveScores()
+		}
+	}
+
+	q.Input, cmd = q.Input.Update(msg)
+	return q, cmd
+}
+
+func (q QuizModel) body() string {
+	lines := []string{
+		fmt.Sprintf("Streak %d  Best streak %d  Correct %d/%d", q.Scores.Streak, q.Scores.BestStreak, q.Scores.Correct, q.Scores.Played),
+	}
+	if q.ScoresErr != nil {
+		lines = append(lines, q.ScoresErr.Error())
+	}
+	lines = append(lines, "")
+
+	switch {
+	case q.Err != nil:
+		lines = append(lines, q.Err.Error(), "", "enter - try another pokemon")
+		return strings.Join(lines, "\n")
+	case q.Loading || q.Pokemon.Name == "":
+		lines = append(lines, loadingView(q.spinner))
+		return strings.Join(lines, "\n")
+	case q.Revealed:
+		lines = append(lines, q.Sprite)
+	default:
+		lines = append(lines, q.Silhouette)
+	}
+
+	lines = append(lines, "")
+	if q.Hints >= 1 || q.Revealed {
+		lines = append(lines, "Type: "+strings.Join(q.Pokemon.Types, ", "))
+	}
+	if q.Hints >= 2 || q.Revealed {
+		lines = append(lines, "Generation: "+generationName(generationOfID(q.Pokemon.ID)))
+	}
+	if q.Hints < 2 && !q.Revealed {
+		lines = append(lines, "(enter with no guess - hint)")
+	}
+	if q.Message != "" {
+		lines = append(lines, "", q.Message)
+	}
+	return strings.Join(lines, "\n")
+}
+
+// View renders the quiz within width and height, including borders.
+func (q QuizModel) View(styles *Styles, width int, height int, focused bool) string {
+	borderStyle, headerStyle, bodyStyle := styles.UnfocusedBorderedStyle, styles.DisplayHeaderUnfocusedStyle, styles.DisplayBodyUnfocusedStyle
+	if focused {
+		borderStyle, headerStyle, bodyStyle = styles.FocusedBorderedStyle, styles.DisplayHeaderFocusedStyle, styles.DisplayBodyFocusedStyle
+	}
+
+	return borderStyle.Height(height).Width(width - 2).Render(
+		lipgloss.JoinVertical(
+			lipgloss.Left,
+			/* DISPLAY */
+			borderStyle.Height(height-5).Width(width-4).Render(
+				lipgloss.JoinVertical(
+					lipgloss.Left,
+					headerStyle.Render("Who's That Pokemon?"),
+					bodyStyle.Render(q.body()),
+				),
+			),
+			/* INPUT */
+			borderStyle.Width(width-4).Render(q.Input.View()),
+		),
+	)
+}
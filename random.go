@@ -0,0 +1,216 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"math/rand"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// RandomConstraints restrict the pokemon randomPokemon picks from, zero
+// values mean no restriction and a zero Seed picks a random seed.
+type RandomConstraints struct {
+	Generation    int
+	Type          string
+	FullyEvolved  bool
+	NoLegendaries bool
+	Seed          int64
+}
+
+type RandomTeamMsg struct {
+	Team []Pokemon
+	Seed int64
+	Err  error
+}
+
+// randomPool returns the national numbers of the species matching the
+// generation and type constraints, in ascending order.
+func randomPool(c RandomConstraints) ([]int, error) {
+	pool := []int{}
+	if c.Generation > 0 {
+		entries, err := getGenerationPokemon(fmt.Sprintf("generation-%s", generations[c.Generation-1]))
+		if err != nil {
+			return nil, err
+		}
+		for _, entry := range entries {
+			pool = append(pool, entry.Number)
+		}
+	} else {
+		var listResponse PokemonListResponse
+		err := fetchJSON(POKEAPI_URL+"pokemon-species/?limit=100000", "pokemon species list", &listResponse)
+		if err != nil {
+			return nil, err
+		}
+		for _, species := range listResponse.Results {
+			pool = append(pool, resourceID(species.URL))
+		}
+	}
+
+	if c.Type != "" {
+		var typeResponse TypeResponse
+		err := fetchJSON(POKEAPI_URL+"type/"+c.Type, "type", &typeResponse)
+		if err != nil {
+			return nil, err
+		}
+		// The default variety of a species shares its national number, other
+		// varieties are numbered from 10001.
+		ofType := map[int]bool{}
+		for _, pokemon := range typeResponse.Pokemon {
+			ofType[resourceID(pokemon.Pokemon.URL)] = true
+		}
+		filtered := []int{}
+		for _, id := range pool {
+			if ofType[id] {
+				filtered = append(filtered, id)
+			}
+		}
+		pool = filtered
+	}
+
+	sort.Ints(pool)
+	return pool, nil
+}
+
+func isFullyEvolved(chain EvolutionChainLink, species string) bool {
+	if chain.Species.Name == species {
+		return len(chain.EvolvesTo) == 0
+	}
+	for _, link := range chain.EvolvesTo {
+		if isFullyEvolved(link, species) {
+			return true
+		}
+	}
+	return false
+}
+
+// matchesSpecies checks the constraints that need the species of id.
+func matchesSpecies(c RandomConstraints, id int, chains map[string]EvolutionChainResponse) (bool, error) {
+	if !c.FullyEvolved && !c.NoLegendaries {
+		return true, nil
+	}
+
+	species, err := getPokemonSpecies(strconv.Itoa(id))
+	if err != nil {
+		return false, err
+	}
+	if c.NoLegendaries && (species.IsLegendary || species.IsMythical) {
+		return false, nil
+	}
+	if c.FullyEvolved {
+		chain, ok := chains[species.EvolutionChain.URL]
+		if !ok {
+			err := fetchJSON(species.EvolutionChain.URL, "evolution chain", &chain)
+			if err != nil {
+				return false, err
+			}
+			chains[species.EvolutionChain.URL] = chain
+		}
+		return isFullyEvolved(chain.Chain, species.Name), nil
+	}
+	return true, nil
+}
+
+// randomPokemon picks count distinct pokemon matching c, the same seed and
+// constraints always pick the same pokemon. It returns the seed it used.
+func randomPokemon(c RandomConstraints, count int) ([]Pokemon, int64, error) {
+	seed := c.Seed
+	if seed == 0 {
+		seed = time.Now().UnixNano() % 1000000
+	}
+
+	pool, err := randomPool(c)
+	if err != nil {
+		return nil, seed, err
+	}
+	r := rand.New(rand.NewSource(seed))
+	r.Shuffle(len(pool), func(i, j int) {
+		pool[i], pool[j] = pool[j], pool[i]
+	})
+
+	picks := []Pokemon{}
+	chains := map[string]EvolutionChainResponse{}
+	for _, id := range pool {
+		if len(picks) == count {
+			break
+		}
+		ok, err := matchesSpecies(c, id, chains)
+		if err != nil {
+			return nil, seed, err
+		}
+		if !ok {
+			continue
+		}
+		pokemon, err := getPokemon(strconv.Itoa(id))
+		if err != nil {
+			return nil, seed, err
+		}
+		picks = append(picks, pokemon)
+	}
+
+	if len(picks) == 0 {
+		return nil, seed, fmt.Errorf("no pokemon matches the constraints")
+	}
+	return picks, seed, nil
+}
+
+// randomPokemonCmd picks a random pokemon to display in the pokedex.
+func randomPokemonCmd(c RandomConstraints) tea.Cmd {
+	return func() tea.Msg {
+		picks, _, err := randomPokemon(c, 1)
+		if err != nil {
+			return PokemonErrorMsg{Route: "Pokedex", Err: err}
+		}
+		return PokemonMsg{Pokemon: picks[0]}
+	}
+}
+
+func randomTeamCmd(c RandomConstraints) tea.Cmd {
+	return func() tea.Msg {
+		team, seed, err := randomPokemon(c, 6)
+		return RandomTeamMsg{Team: team, Seed: seed, Err: err}
+	}
+}
+
+// runRandomCommand implements the random command, printing a random pokemon
+// or team.
+func runRandomCommand(args []string) error {
+	fs := flag.NewFlagSet("random", flag.ContinueOnError)
+	team := fs.Bool("team", false, "pick a team of six")
+	generation := fs.Int("gen", 0, "only pick pokemon introduced in generation `n`")
+	pokemonType := fs.String("type", "", "only pick pokemon of `type`")
+	fullyEvolved := fs.Bool("fully-evolved", false, "only pick fully evolved pokemon")
+	noLegendaries := fs.Bool("no-legendaries", false, "do not pick legendary or mythical pokemon")
+	seed := fs.Int64("seed", 0, "seed for reproducible picks, random if 0")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if *generation < 0 || *generation > latestGeneration {
+		return fmt.Errorf("generation must be between 1 and %d", latestGeneration)
+	}
+
+	count := 1
+	if *team {
+		count = 6
+	}
+	picks, usedSeed, err := randomPokemon(RandomConstraints{
+		Generation:    *generation,
+		Type:          strings.ToLower(*pokemonType),
+		FullyEvolved:  *fullyEvolved,
+		NoLegendaries: *noLegendaries,
+		Seed:          *seed,
+	}, count)
+	if err != nil {
+		return err
+	}
+
+	for _, pokemon := range picks {
+		fmt.Printf("%s (%s)\n", pokemon.Name, strings.Join(pokemon.Types, ", "))
+	}
+	fmt.Printf("seed: %d\n", usedSeed)
+	return nil
+}
@@ -0,0 +1,127 @@
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"image"
+	"image/color"
+	"image/png"
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+)
+
+// getSprite fetches and decodes the PNG sprite at url, the PNG is kept in
+// the response cache.
+func getSprite(url string) (image.Image, error) {
+	if url == "" {
+		return nil, fmt.Errorf("no sprite")
+	}
+
+	body, err := fetchBytes(url, "sprite")
+	if err != nil {
+		return nil, err
+	}
+	img, err := png.Decode(bytes.NewReader(body))
+	if err != nil {
+		return nil, &APIError{Kind: DecodeError, Resource: "sprite", Err: err}
+	}
+	return img, nil
+}
+
+// cropSprite returns the bounds of the non transparent pixels of img, sprites
+// are mostly transparent padding.
+func cropSprite(img image.Image) image.Rectangle {
+	b := img.Bounds()
+	crop := image.Rectangle{}
+	for y := b.Min.Y; y < b.Max.Y; y++ {
+		for x := b.Min.X; x < b.Max.X; x++ {
+			if isTransparent(img.At(x, y)) {
+				continue
+			}
+			crop = crop.Union(image.Rect(x, y, x+1, y+1))
+		}
+	}
+	return crop
+}
+
+func isTransparent(c color.Color) bool {
+	_, _, _, a := c.RGBA()
+	return a < 0x8000
+}
+
+func hexColor(c color.Color) lipgloss.Color {
+	r, g, b, _ := c.RGBA()
+	return lipgloss.Color(fmt.Sprintf("#%02x%02x%02x", r>>8, g>>8, b>>8))
+}
+
+// renderSprite renders img with half block characters, two pixels per cell,
+// scaled down so it is at most maxWidth cells wide.
+func renderSprite(img image.Image, maxWidth int) string {
+	return renderSpritePixels(img, maxWidth, 0, func(c color.Color) color.Color { return c })
+}
+
+// renderThumbnail renders img like renderSprite scaled down to fit within
+// width by height cells.
+func renderThumbnail(img image.Image, width int, height int) string {
+	return renderSpritePixels(img, width, height, func(c color.Color) color.Color { return c })
+}
+
+// renderSilhouette renders img like renderSprite with every opaque pixel
+// painted in a single color.
+func renderSilhouette(img image.Image, maxWidth int) string {
+	return renderSpritePixels(img, maxWidth, 0, func(c color.Color) color.Color {
+		return color.RGBA{0x22, 0x22, 0x33, 0xff}
+	})
+}
+
+// renderSpritePixels renders img at most maxWidth cells wide, and maxHeight
+// cells high unless it is 0, painting each pixel with paint.
+func renderSpritePixels(img image.Image, maxWidth int, maxHeight int, paint func(color.Color) color.Color) string {
+	crop := cropSprite(img)
+	if crop.Empty() || maxWidth <= 0 {
+		return ""
+	}
+
+	scale := (crop.Dx() + maxWidth - 1) / maxWidth
+	if maxHeight > 0 {
+		scale = max(scale, (crop.Dy()+2*maxHeight-1)/(2*maxHeight))
+	}
+	if scale < 1 {
+		scale = 1
+	}
+	width := crop.Dx() / scale
+	height := crop.Dy() / scale
+
+	pixel := func(x, y int) (color.Color, bool) {
+		if y >= height {
+			return nil, false
+		}
+		c := img.At(crop.Min.X+x*scale, crop.Min.Y+y*scale)
+		if isTransparent(c) {
+			return nil, false
+		}
+		return paint(c), true
+	}
+
+	lines := []string{}
+	for y := 0; y < height; y += 2 {
+		var line strings.Builder
+		for x := 0; x < width; x++ {
+			top, hasTop := pixel(x, y)
+			bottom, hasBottom := pixel(x, y+1)
+			switch {
+			case hasTop && hasBottom:
+				line.WriteString(lipgloss.NewStyle().Foreground(hexColor(top)).Background(hexColor(bottom)).Render("▀"))
+			case hasTop:
+				line.WriteString(lipgloss.NewStyle().Foreground(hexColor(top)).Render("▀"))
+			case hasBottom:
+				line.WriteString(lipgloss.NewStyle().Foreground(hexColor(bottom)).Render("▄"))
+			default:
+				line.WriteString(" ")
+			}
+		}
+		lines = append(lines, line.String())
+	}
+	return strings.Join(lines, "\n")
+}
@@ -0,0 +1,198 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+)
+
+type Nature struct {
+	Name     string
+	Increase string
+	Decrease string
+}
+
+// natures lists the natures in their index order, neutral natures increase
+// and decrease nothing.
+var natures = []Nature{
+	{"hardy", "", ""},
+	{"lonely", "attack", "defense"},
+	{"brave", "attack", "speed"},
+	{"adamant", "attack", "special-attack"},
+	{"naughty", "attack", "special-defense"},
+	{"bold", "defense", "attack"},
+	{"docile", "", ""},
+	{"relaxed", "defense", "speed"},
+	{"impish", "defense", "special-attack"},
+	{"lax", "defense", "special-defense"},
+	{"timid", "speed", "attack"},
+	{"hasty", "speed", "defense"},
+	{"serious", "", ""},
+	{"jolly", "speed", "special-attack"},
+	{"naive", "speed", "special-defense"},
+	{"modest", "special-attack", "attack"},
+	{"mild", "special-attack", "defense"},
+	{"quiet", "special-attack", "speed"},
+	{"bashful", "", ""},
+	{"rash", "special-attack", "special-defense"},
+	{"calm", "special-defense", "attack"},
+	{"gentle", "special-defense", "defense"},
+	{"sassy", "special-defense", "speed"},
+	{"careful", "special-defense", "special-attack"},
+	{"quirky", "", ""},
+}
+
+// natureModifier returns the nature multiplier of stat in percent.
+func natureModifier(nature Nature, stat string) int {
+	switch stat {
+	case nature.Increase:
+		return 110
+	case nature.Decrease:
+		return 90
+	}
+	return 100
+}
+
+const (
+	maxIV      = 31
+	maxEV      = 252
+	maxEVTotal = 510
+)
+
+// calcStat computes the actual value of a stat with the formula used since
+// generation III, natureModifier is in percent and ignored for hp.
+func calcStat(stat string, base int, iv int, ev int, level int, natureModifier int) int {
+	// Shedinja is the only pokemon with a base hp of 1, and always has 1 hp.
+	if stat == "hp" && base == 1 {
+		return 1
+	}
+
+	value := (2*base + iv + ev/4) * level / 100
+	if stat == "hp" {
+		return value + level + 10
+	}
+	return (value + 5) * natureModifier / 100
+}
+
+// statRange returns the lowest and highest value of a stat at level, from a
+// hindering nature with no IVs and EVs to a beneficial nature maxed out.
+func statRange(stat string, base int, level int) (int, int) {
+	return calcStat(stat, base, 0, 0, level, 90), calcStat(stat, base, maxIV, maxEV, level, 110)
+}
+
+var statNames = []string{"hp", "attack", "defense", "special-attack", "special-defense", "speed"}
+
+// StatCalc holds the settings of the stat calculator, IVs and EVs are
+// indexed like statNames.
+type StatCalc struct {
+	Level  int
+	Nature int
+	IVs    [6]int
+	EVs    [6]int
+	// Cursor selects the level, the nature, then the IV and EV of each stat.
+	Cursor  int
+	Warning string
+}
+
+func NewStatCalc() StatCalc {
+	return StatCalc{
+		Level:  50,
+		IVs:    [6]int{maxIV, maxIV, maxIV, maxIV, maxIV, maxIV},
+		Nature: 0,
+	}
+}
+
+func (c StatCalc) evTotal() int {
+	total := 0
+	for _, ev := range c.EVs {
+		total += ev
+	}
+	return total
+}
+
+func (c StatCalc) cursorCount() int {
+	return 2 + 2*len(statNames)
+}
+
+// adjust changes the setting under the cursor by step, big steps jump to the
+// bounds of IVs and EVs and by 10 levels.
+func (c StatCalc) adjust(step int, big bool) StatCalc {
+	c.Warning = ""
+	switch {
+	case c.Cursor == 0:
+		if big {
+			step *= 10
+		}
+		c.Level = max(1, min(100, c.Level+step))
+	case c.Cursor == 1:
+		c.Nature = (c.Nature + len(natures) + step) % len(natures)
+	case (c.Cursor-2)%2 == 0:
+		stat := (c.Cursor - 2) / 2
+		if big {
+			step *= maxIV
+		}
+		c.IVs[stat] = max(0, min(maxIV, c.IVs[stat]+step))
+	default:
+		stat := (c.Cursor - 2) / 2
+		step *= 4
+		if big {
+			step = step / 4 * maxEV
+		}
+		ev := max(0, min(maxEV, c.EVs[stat]+step))
+		if remaining := maxEVTotal - c.evTotal() + c.EVs[stat]; ev > remaining {
+			ev = remaining
+			c.Warning = fmt.Sprintf("EV total can not exceed %d", maxEVTotal)
+		}
+		c.EVs[stat] = ev
+	}
+	return c
+}
+
+func (c StatCalc) View(pokemon Pokemon) string {
+	cell := func(cursor int, value string) string {
+		if cursor == c.Cursor {
+			return "[" + value + "]"
+		}
+		return " " + value + " "
+	}
+
+	nature := natures[c.Nature]
+	natureDesc := "neutral"
+	if nature.Increase != "" {
+		natureDesc = "+" + nature.Increase + " -" + nature.Decrease
+	}
+
+	lines := []string{
+		"Stat calculator (up/down - select, left/right - adjust, shift+left/right - adjust more)",
+		"",
+		fmt.Sprintf("Level  %s", cell(0, fmt.Sprintf("%3d", c.Level))),
+		fmt.Sprintf("Nature %s (%s)", cell(1, nature.Name), natureDesc),
+		"",
+		fmt.Sprintf("%-16s %4s %5s %5s %5s %11s %11s", "", "base", "IV", "EV", "stat", "Lv.50", "Lv.100"),
+	}
+
+	bases := map[string]int{}
+	for _, stat := range pokemon.Stats {
+		bases[stat.Name] = stat.Base
+	}
+	for i, stat := range statNames {
+		base := bases[stat]
+		value := calcStat(stat, base, c.IVs[i], c.EVs[i], c.Level, natureModifier(nature, stat))
+		min50, max50 := statRange(stat, base, 50)
+		min100, max100 := statRange(stat, base, 100)
+		lines = append(lines, fmt.Sprintf("%-16s %4d %5s %5s %5d %11s %11s",
+			stat,
+			base,
+			cell(2+2*i, fmt.Sprintf("%3d", c.IVs[i])),
+			cell(3+2*i, fmt.Sprintf("%3d", c.EVs[i])),
+			value,
+			fmt.Sprintf("%d-%d", min50, max50),
+			fmt.Sprintf("%d-%d", min100, max100),
+		))
+	}
+
+	lines = append(lines, "", fmt.Sprintf("EV total %d/%d", c.evTotal(), maxEVTotal))
+	if c.Warning != "" {
+		lines = append(lines, c.Warning)
+	}
+	return strings.Join(lines, "\n")
+}
@@ -0,0 +1,27 @@
+package main
+
+import "testing"
+
+func TestCalcStat(t *testing.T) {
+	tests := []struct {
+		stat   string
+		base   int
+		iv     int
+		ev     int
+		level  int
+		nature int
+		want   int
+	}{
+		// Level 50 adamant garchomp with 252 hp and attack EVs.
+		{stat: "hp", base: 108, iv: 31, ev: 252, level: 50, nature: 100, want: 215},
+		{stat: "attack", base: 130, iv: 31, ev: 252, level: 50, nature: 110, want: 200},
+		{stat: "special-attack", base: 80, iv: 31, ev: 0, level: 50, nature: 90, want: 90},
+		{stat: "hp", base: 1, iv: 31, ev: 252, level: 100, nature: 100, want: 1},
+	}
+
+	for _, tt := range tests {
+		if got := calcStat(tt.stat, tt.base, tt.iv, tt.ev, tt.level, tt.nature); got != tt.want {
+			t.Errorf("calcStat(%s, %d) = %d, want %d", tt.stat, tt.base, got, tt.want)
+		}
+	}
+}
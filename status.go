@@ -0,0 +1,105 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+var statusBarStyle = lipgloss.NewStyle().
+	Foreground(lipgloss.Color("#888888")).
+	PaddingLeft(1).
+	PaddingRight(1)
+
+// loadingView renders the loading state of a panel with the spinner frame.
+func loadingView(spinner string) string {
+	return spinner + " Loading..."
+}
+
+// messageError returns the error carried by msg and the route it was made
+// for, nil if msg carries none.
+func messageError(msg tea.Msg) (string, error) {
+	switch msg := msg.(type) {
+	case PokemonErrorMsg:
+		return msg.Route, msg.Err
+	case PokemonEncountersMsg:
+		return "Pokedex", msg.Err
+	case PokemonSpeciesMsg:
+		return "Pokedex", msg.Err
+	case PokemonFormMsg:
+		return "Pokedex", msg.Err
+	case ItemMsg:
+		return "Pokedex", msg.Err
+	case CryMsg:
+		return "Pokedex", msg.Err
+	case BrowserListMsg:
+		return msg.Route, msg.Err
+	case BrowserDetailMsg:
+		return msg.Route, msg.Err
+	case DamageCalcPokemonMsg:
+		return "Damage Calc", msg.Err
+	case DamageCalcMoveMsg:
+		return "Damage Calc", msg.Err
+	case QuizPokemonMsg:
+		return "Quiz", msg.Err
+	case RandomTeamMsg:
+		return "Team", msg.Err
+	}
+	return "", nil
+}
+
+// busy reports whether the spinner has something to animate or the toasts
+// have to expire, the spinner stops ticking otherwise.
+func (m Model) busy() bool {
+	return currentFetchStats().Pending > 0 || len(m.Toasts) > 0 || m.Palette.NamesLoading ||
+		m.Pokedex.Loading || m.PokemonList.Loading || m.PokemonList.Indexing || m.Quiz.Loading || m.Team.Loading ||
+		m.Moves.Loading || m.Moves.LoadingDetail != "" || m.Items.Loading || m.Items.LoadingDetail != "" ||
+		m.Berries.Loading || m.Berries.LoadingDetail != ""
+}
+
+// spin schedules a spinner tick when busy, or when cmd may start a fetch
+// which the next tick finds pending. The ticks stop once idle.
+func (m Model) spin(cmd tea.Cmd) (Model, tea.Cmd) {
+	if m.ticking || (cmd == nil && !m.busy()) {
+		return m, cmd
+	}
+	m.ticking = true
+	return m, tea.Batch(cmd, m.Spinner.Tick)
+}
+
+// withSpinner hands the current spinner frame to the routes rendering a
+// loading state.
+func (m Model) withSpinner() Model {
+	frame := m.Spinner.View()
+	m.Pokedex.spinner = frame
+	m.PokemonList.spinner = frame
+	m.Moves.spinner = frame
+	m.Items.spinner = frame
+	m.Berries.spinner = frame
+	m.Quiz.spinner = frame
+	m.Team.spinner = frame
+	return m
+}
+
+// statusBar renders the current route, the requests in flight, the response
+// cache usage and the last error on a single line.
+func (m Model) statusBar() string {
+	stats := currentFetchStats()
+
+	pending := "idle"
+	if stats.Pending > 0 {
+		pending = fmt.Sprintf("%s %d pending", m.Spinner.View(), stats.Pending)
+	}
+	parts := []string{
+		m.currentRoute(),
+		pending,
+		fmt.Sprintf("cache %d hits / %d misses", stats.Hits, stats.Misses),
+	}
+	if m.LastError != nil {
+		parts = append(parts, fmt.Sprintf("%s %s", m.LastErrorAt.Format("15:04:05"), m.LastError))
+	}
+
+	return statusBarStyle.MaxWidth(m.Width).Render(strings.Join(parts, " | "))
+}
@@ -0,0 +1,222 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/key"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+const teamSize = 6
+
+// TeamModel is the team route, a team of up to six pokemon which can be
+// generated randomly within constraints.
+type TeamModel struct {
+	Members     []Pokemon
+	Constraints RandomConstraints
+	// Cursor selects the constraints, the actions, then the members.
+	Cursor    int
+	Loading   bool
+	LastSeed  int64
+	isFocused bool
+	spinner   string
+}
+
+var teamConstraintRows = []string{"Generation", "Type", "Fully evolved", "No legendaries", "Seed"}
+
+var teamActionRows = []string{"Generate random team", "Random pokemon in the Pokedex"}
+
+// FocusRouteMsg moves the focus to Route.
+type FocusRouteMsg struct {
+	Route string
+}
+
+// OpenPokemonMsg displays the pokemon named Name in the pokedex.
+type OpenPokemonMsg struct {
+	Name string
+}
+
+func (t TeamModel) rowCount() int {
+	return len(teamConstraintRows) + len(teamActionRows) + len(t.Members)
+}
+
+// Add adds pokemon to the team, replacing the last member of a full team.
+func (t TeamModel) Add(pokemon Pokemon) TeamModel {
+	if len(t.Members) == teamSize {
+		t.Members = t.Members[:teamSize-1]
+	}
+	t.Members = append(t.Members, pokemon)
+	return t
+}
+
+func (t TeamModel) generate() (TeamModel, tea.Cmd) {
+	t.Loading = true
+	return t, randomTeamCmd(t.Constraints)
+}
+
+func init() {
+	registerPaletteAction(PaletteAction{
+		Title: "Generate random team",
+		Route: "Team",
+		Run: func(m Model) (Model, tea.Cmd) {
+			m, cmd := m.focusRoute("Team")
+			var teamCmd tea.Cmd
+			m.Team, teamCmd = m.Team.generate()
+			return m, tea.Batch(cmd, teamCmd)
+		},
+	})
+}
+
+func (t TeamModel) Update(msg tea.Msg) (TeamModel, tea.Cmd) {
+	switch msg := msg.(type) {
+	case RandomTeamMsg:
+		t.Loading = false
+		t.LastSeed = msg.Seed
+		// A failed generation keeps the team, its error is shown as a toast.
+		if msg.Err == nil {
+			t.Members = msg.Team
+		}
+
+	case tea.KeyMsg:
+		k := t.keys(false)
+		switch {
+		case key.Matches(msg, k.Up):
+			if t.Cursor > 0 {
+				t.Cursor--
+			}
+		case key.Matches(msg, k.Down):
+			if t.Cursor < t.rowCount()-1 {
+				t.Cursor++
+			}
+		case key.Matches(msg, k.Adjust.bindings()...):
+			t.Constraints = t.adjust(k.Adjust.step(msg))
+		case key.Matches(msg, k.Random):
+			return t.generate()
+		case key.Matches(msg, k.Remove):
+			member := t.Cursor - len(teamConstraintRows) - len(teamActionRows)
+			if member >= 0 {
+				t.Members = append(t.Members[:member:member], t.Members[member+1:]...)
+				t.Cursor = min(t.Cursor, t.rowCount()-1)
+			}
+		case key.Matches(msg, k.Select):
+			switch row := t.Cursor - len(teamConstraintRows); {
+			case row < 0:
+				t.Constraints = t.adjust(1, false)
+			case row == 0:
+				return t.generate()
+			case row == 1:
+				return t, tea.Batch(
+					func() tea.Msg { return FocusRouteMsg{Route: "Pokedex"} },
+					randomPokemonCmd(t.Constraints),
+				)
+			default:
+				name := t.Members[row-len(teamActionRows)].Name
+				return t, func() tea.Msg { return OpenPokemonMsg{Name: name} }
+			}
+		}
+	}
+	return t, nil
+}
+
+// adjust changes the constraint under the cursor, big steps change the seed
+// by 100.
+func (t TeamModel) adjust(step int, big bool) RandomConstraints {
+	c := t.Constraints
+	switch t.Cursor {
+	case 0:
+		c.Generation = (c.Generation + latestGeneration + 1 + step) % (latestGeneration + 1)
+	case 1:
+		types := append([]string{""}, pokemonTypes...)
+		current := 0
+		for i, pokemonType := range types {
+			if pokemonType == c.Type {
+				current = i
+			}
+		}
+		c.Type = types[(current+len(types)+step)%len(types)]
+	case 2:
+		c.FullyEvolved = !c.FullyEvolved
+	case 3:
+		c.NoLegendaries = !c.NoLegendaries
+	case 4:
+		if big {
+			step *= 100
+		}
+		c.Seed = max(0, c.Seed+int64(step))
+	}
+	return c
+}
+
+// body renders the lines of the team that fit in width and height, scrolled
+// to keep the cursor visible.
+func (t TeamModel) body(width int, height int) string {
+	c := t.Constraints
+	yesNo := func(v bool) string {
+		if v {
+			return "yes"
+		}
+		return "no"
+	}
+	values := []string{"any", "any", yesNo(c.FullyEvolved), yesNo(c.NoLegendaries), "random"}
+	if c.Generation > 0 {
+		values[0] = generationName(c.Generation)
+	}
+	if c.Type != "" {
+		values[1] = c.Type
+	}
+	if c.Seed > 0 {
+		values[4] = fmt.Sprintf("%d", c.Seed)
+	}
+
+	cursorLine := 0
+	lines := []string{"Random constraints (left/right - adjust)", ""}
+	cursor := func(row int) string {
+		if row == t.Cursor {
+			cursorLine = len(lines)
+			return "> "
+		}
+		return "  "
+	}
+
+	for i, row := range teamConstraintRows {
+		lines = append(lines, fmt.Sprintf("%s%-16s%s", cursor(i), row, values[i]))
+	}
+	lines = append(lines, "")
+	for i, row := range teamActionRows {
+		lines = append(lines, cursor(len(teamConstraintRows)+i)+row)
+	}
+
+	lines = append(lines, "", fmt.Sprintf("Team %d/%d (enter - open in Pokedex, x - remove, r - random team)", len(t.Members), teamSize), "")
+	switch {
+	case t.Loading:
+		lines = append(lines, loadingView(t.spinner))
+	case len(t.Members) == 0:
+		lines = append(lines, "No pokemon in the team")
+	}
+	for i, member := range t.Members {
+		row := len(teamConstraintRows) + len(teamActionRows) + i
+		lines = append(lines, fmt.Sprintf("%s%-16s%s", cursor(row), member.Name, strings.Join(member.Types, ", ")))
+	}
+	if t.LastSeed != 0 && !t.Loading {
+		lines = append(lines, "", fmt.Sprintf("Generated with seed %d", t.LastSeed))
+	}
+	return scrollLines(lines, cursorLine, width, height)
+}
+
+// View renders the team within width and height, including borders.
+func (t TeamModel) View(styles *Styles, width int, height int, focused bool) string {
+	borderStyle, headerStyle, bodyStyle := styles.UnfocusedBorderedStyle, styles.DisplayHeaderUnfocusedStyle, styles.DisplayBodyUnfocusedStyle
+	if focused {
+		borderStyle, headerStyle, bodyStyle = styles.FocusedBorderedStyle, styles.DisplayHeaderFocusedStyle, styles.DisplayBodyFocusedStyle
+	}
+
+	return borderStyle.Height(height).Width(width - 2).Render(
+		lipgloss.JoinVertical(
+			lipgloss.Left,
+			headerStyle.Render("Team"),
+			bodyStyle.Render(t.body(width-2-bodyStyle.GetHorizontalFrameSize(), height-1-bodyStyle.GetVerticalFrameSize())),
+		),
+	)
+}
@@ -0,0 +1,125 @@
+package main
+
+import (
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/charmbracelet/x/ansi"
+)
+
+// Toast notifies an error in the route it was caused by, until it expires
+// or is dismissed.
+type Toast struct {
+	ID    int
+	Route string
+	Err   error
+	At    time.Time
+}
+
+const (
+	toastDuration = 8 * time.Second
+	toastWidth    = 40
+	// maxToasts is the number of toasts kept per route, older ones are
+	// dropped.
+	maxToasts = 3
+)
+
+var toastBorderColors = map[APIErrorKind]lipgloss.Color{
+	StatusError:      lipgloss.Color("#cc3333"),
+	NotFoundError:    lipgloss.Color("#cc8833"),
+	RateLimitedError: lipgloss.Color("#cccc33"),
+	NetworkError:     lipgloss.Color("#cc3333"),
+	DecodeError:      lipgloss.Color("#cc33cc"),
+}
+
+// pushToast adds a toast for err in route.
+func (m Model) pushToast(route string, err error) Model {
+	m.nextToastID++
+	toasts := []Toast{}
+	count := 0
+	for i := len(m.Toasts) - 1; i >= 0; i-- {
+		if m.Toasts[i].Route == route {
+			count++
+			if count >= maxToasts {
+				continue
+			}
+		}
+		toasts = append([]Toast{m.Toasts[i]}, toasts...)
+	}
+	m.Toasts = append(toasts, Toast{ID: m.nextToastID, Route: route, Err: err, At: time.Now()})
+	return m
+}
+
+// expireToasts drops the toasts older than toastDuration.
+func (m Model) expireToasts(now time.Time) Model {
+	toasts := []Toast{}
+	for _, toast := range m.Toasts {
+		if now.Sub(toast.At) < toastDuration {
+			toasts = append(toasts, toast)
+		}
+	}
+	m.Toasts = toasts
+	return m
+}
+
+// dismissToast drops the latest toast of the current route.
+func (m Model) dismissToast() Model {
+	for i := len(m.Toasts) - 1; i >= 0; i-- {
+		if m.Toasts[i].Route == m.currentRoute() {
+			m.Toasts = append(m.Toasts[:i:i], m.Toasts[i+1:]...)
+			break
+		}
+	}
+	return m
+}
+
+func toastView(toast Toast) string {
+	kind := errorKind(toast.Err)
+	return lipgloss.NewStyle().
+		Border(lipgloss.RoundedBorder(), true).
+		BorderForeground(toastBorderColors[kind]).
+		Padding(0, 1).
+		Width(toastWidth).
+		Render(
+			lipgloss.JoinVertical(
+				lipgloss.Left,
+				lipgloss.NewStyle().Bold(true).Foreground(toastBorderColors[kind]).Render(kind.String()),
+				toast.Err.Error(),
+				lipgloss.NewStyle().Foreground(lipgloss.Color("#666666")).Render("ctrl+x - dismiss"),
+			),
+		)
+}
+
+// withToasts draws the toasts of the current route stacked over the bottom
+// right corner of view, keeping its bottom border visible.
+func (m Model) withToasts(view string) string {
+	boxes := []string{}
+	for _, toast := range m.Toasts {
+		if toast.Route == m.currentRoute() {
+			boxes = append(boxes, toastView(toast))
+		}
+	}
+	if len(boxes) == 0 {
+		return view
+	}
+	return overlayBottomRight(view, lipgloss.JoinVertical(lipgloss.Right, boxes...), m.Width, 1)
+}
+
+// overlayBottomRight draws box over the right edge of view, bottom lines
+// above its last line, cutting what view draws under and right of box.
+func overlayBottomRight(view string, box string, width int, bottom int) string {
+	lines := strings.Split(view, "\n")
+	boxLines := strings.Split(box, "\n")
+	x := width - lipgloss.Width(box)
+	top := len(lines) - len(boxLines) - bottom
+	if x < 0 || top < 0 {
+		return view
+	}
+
+	for i, boxLine := range boxLines {
+		line := ansi.Truncate(lines[top+i], x, "")
+		lines[top+i] = line + strings.Repeat(" ", max(0, x-ansi.StringWidth(line))) + boxLine
+	}
+	return strings.Join(lines, "\n")
+}
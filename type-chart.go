@@ -0,0 +1,136 @@
+package main
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// generations are the roman numerals of the generations, generationNumber
+// of a PokeAPI generation name is its index plus one.
+var generations = []string{"i", "ii", "iii", "iv", "v", "vi", "vii", "viii", "ix"}
+
+const latestGeneration = 9
+
+// generationNumber parses a PokeAPI generation name such as generation-iv,
+// 0 if the name is unknown.
+func generationNumber(name string) int {
+	numeral := strings.TrimPrefix(name, "generation-")
+	for i, g := range generations {
+		if g == numeral {
+			return i + 1
+		}
+	}
+	return 0
+}
+
+func generationName(generation int) string {
+	if generation < 1 || generation > len(generations) {
+		return "current"
+	}
+	return "Gen " + strings.ToUpper(generations[generation-1])
+}
+
+var pokemonTypes = []string{
+	"normal", "fire", "water", "electric", "grass", "ice", "fighting", "poison", "ground",
+	"flying", "psychic", "bug", "rock", "ghost", "dragon", "dark", "steel", "fairy",
+}
+
+// typeChart holds the multipliers of attacking types against defending types
+// since generation VI, matchups not listed are neutral.
+var typeChart = map[string]map[string]float64{
+	"normal":   {"rock": 0.5, "steel": 0.5, "ghost": 0},
+	"fire":     {"grass": 2, "ice": 2, "bug": 2, "steel": 2, "fire": 0.5, "water": 0.5, "rock": 0.5, "dragon": 0.5},
+	"water":    {"fire": 2, "ground": 2, "rock": 2, "water": 0.5, "grass": 0.5, "dragon": 0.5},
+	"electric": {"water": 2, "flying": 2, "electric": 0.5, "grass": 0.5, "dragon": 0.5, "ground": 0},
+	"grass":    {"water": 2, "ground": 2, "rock": 2, "fire": 0.5, "grass": 0.5, "poison": 0.5, "flying": 0.5, "bug": 0.5, "dragon": 0.5, "steel": 0.5},
+	"ice":      {"grass": 2, "ground": 2, "flying": 2, "dragon": 2, "fire": 0.5, "water": 0.5, "ice": 0.5, "steel": 0.5},
+	"fighting": {"normal": 2, "ice": 2, "rock": 2, "dark": 2, "steel": 2, "poison": 0.5, "flying": 0.5, "psychic": 0.5, "bug": 0.5, "fairy": 0.5, "ghost": 0},
+	"poison":   {"grass": 2, "fairy": 2, "poison": 0.5, "ground": 0.5, "rock": 0.5, "ghost": 0.5, "steel": 0},
+	"ground":   {"fire": 2, "electric": 2, "poison": 2, "rock": 2, "steel": 2, "grass": 0.5, "bug": 0.5, "flying": 0},
+	"flying":   {"grass": 2, "fighting": 2, "bug": 2, "electric": 0.5, "rock": 0.5, "steel": 0.5},
+	"psychic":  {"fighting": 2, "poison": 2, "psychic": 0.5, "steel": 0.5, "dark": 0},
+	"bug":      {"grass": 2, "psychic": 2, "dark": 2, "fire": 0.5, "fighting": 0.5, "poison": 0.5, "flying": 0.5, "ghost": 0.5, "steel": 0.5, "fairy": 0.5},
+	"rock":     {"fire": 2, "ice": 2, "flying": 2, "bug": 2, "fighting": 0.5, "ground": 0.5, "steel": 0.5},
+	"ghost":    {"psychic": 2, "ghost": 2, "dark": 0.5, "normal": 0},
+	"dragon":   {"dragon": 2, "steel": 0.5, "fairy": 0},
+	"dark":     {"psychic": 2, "ghost": 2, "fighting": 0.5, "dark": 0.5, "fairy": 0.5},
+	"steel":    {"ice": 2, "rock": 2, "fairy": 2, "fire": 0.5, "water": 0.5, "electric": 0.5, "steel": 0.5},
+	"fairy":    {"fighting": 2, "dragon": 2, "dark": 2, "fire": 0.5, "poison": 0.5, "steel": 0.5},
+}
+
+// typesInGeneration lists the types that exist in generation, 0 meaning the
+// latest generation.
+func typesInGeneration(generation int) []string {
+	types := []string{}
+	for _, t := range pokemonTypes {
+		if generation == 1 && (t == "dark" || t == "steel") {
+			continue
+		}
+		if generation >= 1 && generation <= 5 && t == "fairy" {
+			continue
+		}
+		types = append(types, t)
+	}
+	return types
+}
+
+// typeEffectiveness returns the multiplier of an attacking type against a
+// single defending type in generation, 0 meaning the latest generation.
+func typeEffectiveness(attacking string, defending string, generation int) float64 {
+	if generation >= 1 && generation <= 5 && defending == "steel" && (attacking == "ghost" || attacking == "dark") {
+		return 0.5
+	}
+	if generation == 1 {
+		switch {
+		case attacking == "bug" && defending == "poison":
+			return 2
+		case attacking == "poison" && defending == "bug":
+			return 2
+		case attacking == "ghost" && defending == "psychic":
+			return 0
+		case attacking == "ice" && defending == "fire":
+			return 1
+		}
+	}
+
+	multiplier, ok := typeChart[attacking][defending]
+	if !ok {
+		return 1
+	}
+	return multiplier
+}
+
+// damageMultiplier returns the multiplier of an attacking type against a
+// pokemon with types.
+func damageMultiplier(attacking string, types []string, generation int) float64 {
+	multiplier := 1.0
+	for _, t := range types {
+		multiplier *= typeEffectiveness(attacking, t, generation)
+	}
+	return multiplier
+}
+
+// typeMatchupsView renders the defensive matchups of types in generation
+// grouped by multiplier, neutral matchups are left out.
+func typeMatchupsView(types []string, generation int) string {
+	groups := map[float64][]string{}
+	for _, attacking := range typesInGeneration(generation) {
+		multiplier := damageMultiplier(attacking, types, generation)
+		if multiplier != 1 {
+			groups[multiplier] = append(groups[multiplier], attacking)
+		}
+	}
+
+	multipliers := []float64{}
+	for multiplier := range groups {
+		multipliers = append(multipliers, multiplier)
+	}
+	sort.Sort(sort.Reverse(sort.Float64Slice(multipliers)))
+
+	lines := []string{}
+	for _, multiplier := range multipliers {
+		lines = append(lines, fmt.Sprintf("  %-6s%s", fmt.Sprintf("x%g", multiplier), strings.Join(groups[multiplier], ", ")))
+	}
+	return strings.Join(lines, "\n")
+}